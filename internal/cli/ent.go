@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/logger"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// entFieldTypes maps the go types of the columns to the builders of the
+// fields of ent, see entgo.io/ent/schema/field.
+var entFieldTypes = map[string]string{
+	"int":       "Int",
+	"int8":      "Int8",
+	"int16":     "Int16",
+	"int32":     "Int32",
+	"int64":     "Int64",
+	"uint":      "Uint",
+	"uint8":     "Uint8",
+	"uint16":    "Uint16",
+	"uint32":    "Uint32",
+	"uint64":    "Uint64",
+	"float32":   "Float32",
+	"float64":   "Float",
+	"string":    "String",
+	"bool":      "Bool",
+	"time.Time": "Time",
+	"[]byte":    "Bytes",
+}
+
+// entValueFieldTypes maps the go types of the columns to the builders of the
+// fields of ent taking a value of the type, along with its import.
+var entValueFieldTypes = map[string]struct {
+	builder string
+	value   string
+	imp     string
+}{
+	"uuid.UUID":       {builder: "UUID", value: "uuid.UUID{}", imp: "github.com/google/uuid"},
+	"json.RawMessage": {builder: "JSON", value: "json.RawMessage{}", imp: "encoding/json"},
+}
+
+// createEntSchemaString creates the content of the file of the ent schema of
+// the table along with the name of the schema. The types of the fields are
+// the ones of the struct of the table, those ent has no builder for fall back
+// to string.
+func createEntSchemaString(s *settings.Settings, log *logger.Logger, db database.Database, table *database.Table) (string, string, error) {
+
+	tableName, err := structName(s, log, table)
+	if err != nil {
+		return "", "", err
+	}
+
+	// ent handles nullability by the fields itself, and the ids by their name
+	entSettings := *s
+	entSettings.Null = settings.NullTypePrimitive
+	entSettings.IDType = ""
+	entSettings.Decimal = false
+
+	imports := []string{
+		"entgo.io/ent",
+		"entgo.io/ent/dialect/entsql",
+		"entgo.io/ent/schema",
+		"entgo.io/ent/schema/field",
+	}
+
+	// a column is part of the primary key if any of its rows says so, see
+	// ISSUE-4 below
+	var primaryKeys []string
+	for _, column := range table.Columns {
+		if db.IsPrimaryKey(column) && !isStringInSlice(column.Name, primaryKeys) {
+			primaryKeys = append(primaryKeys, column.Name)
+		}
+	}
+	uniques := uniqueColumns(table)
+
+	var fields strings.Builder
+
+	// ent supports an id of a single column only
+	if len(primaryKeys) > 1 {
+		fields.WriteString("// the composite primary key (")
+		fields.WriteString(strings.Join(primaryKeys, ", "))
+		fields.WriteString(") is not supported by ent\n")
+	}
+
+	columns := map[string]struct{}{}
+	for _, column := range table.Columns {
+
+		// ISSUE-4: if columns are part of multiple constraints then the sql
+		// returns multiple rows per column name
+		if _, ok := columns[column.Name]; ok {
+			continue
+		}
+		columns[column.Name] = struct{}{}
+
+		goType, _ := mapDbColumnTypeToGoType(&entSettings, db, table.Name, column)
+		goType = strings.TrimPrefix(goType, "*")
+
+		name := column.Name
+		isID := len(primaryKeys) == 1 && primaryKeys[0] == column.Name
+		if isID {
+			name = "id"
+		}
+
+		var comment string
+		if builder, ok := entFieldTypes[goType]; ok {
+			if builder == "String" && db.IsText(column) {
+				builder = "Text"
+			}
+			fmt.Fprintf(&fields, "field.%s(%s)", builder, strconv.Quote(name))
+		} else if valueType, ok := entValueFieldTypes[goType]; ok {
+			fmt.Fprintf(&fields, "field.%s(%s, %s)", valueType.builder, strconv.Quote(name), valueType.value)
+			if !isStringInSlice(valueType.imp, imports) {
+				imports = append(imports, valueType.imp)
+			}
+		} else {
+			fmt.Fprintf(&fields, "field.String(%s)", strconv.Quote(name))
+			comment = goType + " is not supported by ent"
+		}
+
+		if name != column.Name {
+			fmt.Fprintf(&fields, ".\nStorageKey(%s)", strconv.Quote(column.Name))
+		}
+		if uniques[column.Name] && !isID {
+			fields.WriteString(".\nUnique()")
+		}
+		if db.IsNullable(column) {
+			fields.WriteString(".\nOptional().\nNillable()")
+		}
+		if s.Comments && column.Comment.String != "" {
+			fmt.Fprintf(&fields, ".\nComment(%s)", strconv.Quote(strings.TrimSpace(column.Comment.String)))
+		}
+		fields.WriteString(",")
+		if comment != "" {
+			fields.WriteString(" // ")
+			fields.WriteString(comment)
+		}
+		fields.WriteString("\n")
+	}
+
+	var fileContent strings.Builder
+
+	// write build constraint, separated by a blank line from the package clause
+	if s.BuildTags != "" {
+		fileContent.WriteString(createBuildConstraintString(s))
+	}
+
+	fileContent.WriteString("package ")
+	fileContent.WriteString(s.PackageName)
+	fileContent.WriteString("\n\n")
+
+	sort.Strings(imports)
+	fileContent.WriteString("import (\n")
+	for _, imp := range imports {
+		fileContent.WriteString("\t\"")
+		fileContent.WriteString(imp)
+		fileContent.WriteString("\"\n")
+	}
+	fileContent.WriteString(")\n\n")
+
+	fmt.Fprintf(&fileContent, "// %s holds the schema definition of the table %q.\n", tableName, table.Name)
+	fmt.Fprintf(&fileContent, "type %s struct {\nent.Schema\n}\n\n", tableName)
+
+	fmt.Fprintf(&fileContent, "// Annotations of the %s.\n", tableName)
+	fmt.Fprintf(&fileContent, "func (%s) Annotations() []schema.Annotation {\nreturn []schema.Annotation{\n", tableName)
+	fmt.Fprintf(&fileContent, "entsql.Annotation{Table: %s},\n}\n}\n\n", strconv.Quote(table.Name))
+
+	fmt.Fprintf(&fileContent, "// Fields of the %s.\n", tableName)
+	fmt.Fprintf(&fileContent, "func (%s) Fields() []ent.Field {\nreturn []ent.Field{\n", tableName)
+	fileContent.WriteString(fields.String())
+	fileContent.WriteString("}\n}")
+
+	return tableName, fileContent.String(), nil
+}
+
+// uniqueColumns returns the columns having a unique constraint of their own.
+// The columns of composite unique constraints are unique only together.
+func uniqueColumns(table *database.Table) map[string]bool {
+
+	uniques := map[string]bool{}
+	constraints := map[string][]string{}
+
+	for _, column := range table.Columns {
+		// mysql marks only the first column of composite ones as MUL
+		if column.ColumnKey == "UNI" {
+			uniques[column.Name] = true
+		}
+		if column.ConstraintType.String == "UNIQUE" && column.ConstraintName.Valid {
+			name := column.ConstraintName.String
+			constraints[name] = append(constraints[name], column.Name)
+		}
+	}
+
+	for _, columns := range constraints {
+		if len(columns) == 1 {
+			uniques[columns[0]] = true
+		}
+	}
+
+	return uniques
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/output"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// snapshotFileName is the name of the file in the output file path keeping
+// the hashes of the files written by the last runs.
+const snapshotFileName = ".tables-to-go-snapshot.json"
+
+// snapshotEntry is the hash of a file along with the time of the run which
+// found it changed.
+type snapshotEntry struct {
+	Hash    string    `json:"hash"`
+	Changed time.Time `json:"changed"`
+}
+
+// snapshot keeps the hashes of the files by their names. The hash of the file
+// of a struct covers the metadata of its table, ie. the columns and foreign
+// keys, the hash of the other files covers their content. Both cover the
+// settings as well. Files whose hash did not change since the given time are
+// neither created nor written again.
+type snapshot struct {
+	path  string
+	since time.Time
+	now   time.Time
+
+	// settingsHash is the hash of the settings making up the files
+	settingsHash string
+
+	mu       sync.Mutex
+	previous map[string]snapshotEntry
+	current  map[string]snapshotEntry
+}
+
+// loadSnapshot loads the snapshot of the last run from the output file path
+// of the settings. A missing snapshot file is no error, all files get written
+// then. The given time is the one of the current run.
+func loadSnapshot(s *settings.Settings, now time.Time) (*snapshot, error) {
+
+	hashOfSettings, err := settingsHash(s)
+	if err != nil {
+		return nil, fmt.Errorf("could not hash settings: %w", err)
+	}
+
+	snap := &snapshot{
+		path:         s.OutputFilePath,
+		since:        s.Since.Time,
+		now:          now,
+		settingsHash: hashOfSettings,
+		previous:     map[string]snapshotEntry{},
+		current:      map[string]snapshotEntry{},
+	}
+
+	content, err := os.ReadFile(filepath.Join(s.OutputFilePath, snapshotFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return snap, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot: %w", err)
+	}
+
+	if err = json.Unmarshal(content, &snap.previous); err != nil {
+		return nil, fmt.Errorf("could not parse snapshot %q: %w", snapshotFileName, err)
+	}
+
+	return snap, nil
+}
+
+// settingsHash returns the hash of the settings. The ones not making up the
+// files, like the time of the snapshot or the verbosity, are left out.
+func settingsHash(s *settings.Settings) (string, error) {
+	hashed := *s
+	hashed.Since = settings.Timestamp{}
+	hashed.Verbose, hashed.VVerbose, hashed.Quiet = false, false, false
+	hashed.Concurrency = 0
+	hashed.Pswd = ""
+
+	content, err := json.Marshal(hashed)
+	if err != nil {
+		return "", err
+	}
+	return sha256Hex(content), nil
+}
+
+// tableHash returns the hash of the metadata of the table along with the
+// settings, taken before the struct gets created from them. The given columns
+// of the embedded struct are covered as well, they decide if the table embeds
+// it.
+func (s *snapshot) tableHash(table *database.Table, embedColumns []database.Column) (string, error) {
+	content, err := json.Marshal(struct {
+		Table        *database.Table
+		EmbedColumns []database.Column
+	}{table, embedColumns})
+	if err != nil {
+		return "", err
+	}
+	return sha256Hex([]byte(s.settingsHash), content), nil
+}
+
+// contentHash returns the hash of the content of a file along with the
+// settings, which decorate the content on write, eg. by a header.
+func (s *snapshot) contentHash(content string) string {
+	return sha256Hex([]byte(s.settingsHash), []byte(content))
+}
+
+// sha256Hex returns the hex encoded SHA-256 of the given contents.
+func sha256Hex(contents ...[]byte) string {
+	h := sha256.New()
+	for _, content := range contents {
+		h.Write(content)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isUnchanged returns true if the file of the given name has the same hash as
+// in the last run, was not changed since the time of the snapshot and still
+// exists. The unchanged file is kept in the snapshot of the current run.
+func (s *snapshot) isUnchanged(fileName, hash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.previous[fileName]
+	if !ok || previous.Hash != hash || previous.Changed.After(s.since) {
+		return false
+	}
+	if _, err := os.Stat(filepath.Join(s.path, filepath.FromSlash(fileName)+output.FileWriterExtension)); err != nil {
+		return false
+	}
+
+	s.current[fileName] = previous
+	return true
+}
+
+// keep keeps the hash of the written file of the given name in the snapshot
+// of the current run. The time of the change is kept unless the hash changed.
+func (s *snapshot) keep(fileName, hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := snapshotEntry{Hash: hash, Changed: s.now}
+	if previous, ok := s.previous[fileName]; ok && previous.Hash == hash {
+		entry.Changed = previous.Changed
+	}
+	s.current[fileName] = entry
+}
+
+// save writes the snapshot of the current run, replacing the one of the last
+// run. Files which failed are missing, they get written by the next run.
+func (s *snapshot) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, err := json.MarshalIndent(s.current, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(s.path, snapshotFileName), append(content, '\n'), 0666)
+}
+
+// writeFile writes the content to the file of the given name, unless it is
+// unchanged since the snapshot, if given.
+func writeFile(out output.Writer, snap *snapshot, fileName, content string) error {
+	if snap == nil {
+		return out.Write(fileName, content)
+	}
+
+	hash := snap.contentHash(content)
+	if snap.isUnchanged(fileName, hash) {
+		return nil
+	}
+	if err := out.Write(fileName, content); err != nil {
+		return err
+	}
+	snap.keep(fileName, hash)
+
+	return nil
+}
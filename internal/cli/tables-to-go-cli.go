@@ -1,214 +1,2112 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"go/build/constraint"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/iancoleman/strcase"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
 	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/logger"
 	"github.com/fraenky8/tables-to-go/pkg/output"
 	"github.com/fraenky8/tables-to-go/pkg/settings"
 	"github.com/fraenky8/tables-to-go/pkg/tagger"
 )
 
-var (
-	taggers tagger.Tagger
-	caser   = cases.Title(language.English, cases.NoLower)
+// ErrNoTables is returned if no tables are found to generate structs for and
+// the settings demand to fail then.
+var ErrNoTables = errors.New("no tables found")
+
+// fieldMetaName is the name of the type of the descriptors of the fields,
+// written once per package.
+const fieldMetaName = "FieldMeta"
+
+// registryName is the name of the variable of the names of all tables,
+// written once per package.
+const registryName = "AllTables"
+
+// netTypesName is the name the file of the types of the network address
+// columns is based on, written once per package.
+const netTypesName = "NetTypes"
+
+// netTypesSource are the types of the network address columns. database/sql
+// cannot scan the text of the columns into the types of package net, the
+// types convert it instead. NULL is scanned as nil, like the types of package
+// net are nil by default.
+const netTypesSource = `import (
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NetIP is a net.IP of an inet column.
+type NetIP net.IP
+
+// Scan implements the sql.Scanner interface.
+func (ip *NetIP) Scan(src interface{}) error {
+	if src == nil {
+		*ip = nil
+		return nil
+	}
+	text, err := netText(src)
+	if err != nil {
+		return err
+	}
+	// an inet keeps the netmask of its network, if any
+	if i := strings.IndexByte(text, '/'); i >= 0 {
+		text = text[:i]
+	}
+	parsed := net.ParseIP(text)
+	if parsed == nil {
+		return fmt.Errorf("invalid IP address %q", text)
+	}
+	*ip = NetIP(parsed)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ip NetIP) Value() (driver.Value, error) {
+	if ip == nil {
+		return nil, nil
+	}
+	return net.IP(ip).String(), nil
+}
+
+// NetIPNet is a net.IPNet of a cidr column.
+type NetIPNet net.IPNet
+
+// Scan implements the sql.Scanner interface.
+func (n *NetIPNet) Scan(src interface{}) error {
+	if src == nil {
+		*n = NetIPNet{}
+		return nil
+	}
+	text, err := netText(src)
+	if err != nil {
+		return err
+	}
+	_, parsed, err := net.ParseCIDR(text)
+	if err != nil {
+		return err
+	}
+	*n = NetIPNet(*parsed)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NetIPNet) Value() (driver.Value, error) {
+	ipNet := net.IPNet(n)
+	return ipNet.String(), nil
+}
+
+// NetHardwareAddr is a net.HardwareAddr of a macaddr column.
+type NetHardwareAddr net.HardwareAddr
 
+// Scan implements the sql.Scanner interface.
+func (a *NetHardwareAddr) Scan(src interface{}) error {
+	if src == nil {
+		*a = nil
+		return nil
+	}
+	text, err := netText(src)
+	if err != nil {
+		return err
+	}
+	parsed, err := net.ParseMAC(text)
+	if err != nil {
+		return err
+	}
+	*a = NetHardwareAddr(parsed)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a NetHardwareAddr) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return net.HardwareAddr(a).String(), nil
+}
+
+// netText returns the text of a network address column as given by the driver.
+func netText(src interface{}) (string, error) {
+	switch src := src.(type) {
+	case string:
+		return src, nil
+	case []byte:
+		return string(src), nil
+	}
+	return "", fmt.Errorf("cannot scan %T into a network address", src)
+}`
+
+var (
 	// some strings for idiomatic go in column names
 	// see https://github.com/golang/go/wiki/CodeReviewComments#initialisms
 	initialisms = []string{"ID", "JSON", "XML", "HTTP", "URL"}
+
+	// commonInitialisms are the initialisms known by golint, they get upper
+	// cased if they make up a whole word of a column name
+	commonInitialisms = map[string]bool{
+		"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true,
+		"DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true,
+		"HTTPS": true, "ID": true, "IP": true, "JSON": true, "LHS": true,
+		"QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true,
+		"SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true,
+		"TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true,
+		"URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true,
+		"XMPP": true, "XSRF": true, "XSS": true,
+	}
+
+	// pqArrayTypes maps the udt_name of Postgresql array columns to the array
+	// types of github.com/lib/pq, unsupported element types fall back to []byte
+	pqArrayTypes = map[string]string{
+		"_int2":    "pq.Int64Array",
+		"_int4":    "pq.Int64Array",
+		"_int8":    "pq.Int64Array",
+		"_float4":  "pq.Float64Array",
+		"_float8":  "pq.Float64Array",
+		"_numeric": "pq.Float64Array",
+		"_bool":    "pq.BoolArray",
+		"_bytea":   "pq.ByteaArray",
+		"_text":    "pq.StringArray",
+		"_varchar": "pq.StringArray",
+		"_bpchar":  "pq.StringArray",
+		"_uuid":    "pq.StringArray",
+	}
+
+	// decimalDatatypes are the float datatypes with an exact precision which
+	// can be mapped to decimal.Decimal of github.com/shopspring/decimal
+	decimalDatatypes = []string{"numeric", "decimal"}
+
+	// netTypes maps the Postgresql network address datatypes to the types of
+	// netTypesSource, which wrap the types of the net package
+	netTypes = map[string]string{
+		"inet":     "NetIP",
+		"cidr":     "NetIPNet",
+		"macaddr":  "NetHardwareAddr",
+		"macaddr8": "NetHardwareAddr",
+	}
+
+	// geometryDatatypes are the spatial datatypes of MySQL and the ones of the
+	// PostGIS extension for Postgresql, the latter given by the udt_name
+	geometryDatatypes = []string{
+		"geometry", "geography", "point", "linestring", "polygon", "multipoint",
+		"multilinestring", "multipolygon", "geometrycollection", "geomcollection",
+	}
 )
 
 // Run runs the transformations by creating the concrete Database by the provided settings
 func Run(settings *settings.Settings, db database.Database, out output.Writer) (err error) {
+	return RunContext(context.Background(), settings, db, out)
+}
+
+// RunContext works like Run. The queries of the database get cancelled along
+// with the given context, or if the timeout of the settings elapsed.
+func RunContext(ctx context.Context, settings *settings.Settings, db database.Database, out output.Writer) (err error) {
+
+	if settings.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, settings.Timeout)
+		defer cancel()
+	}
+
+	taggers := tagger.NewTaggers(settings)
+	// the progress must not get mixed into the structs written to stdout
+	log := logger.New(settings.Quiet, settings.Verbose, settings.OutputStdout)
+
+	// sqlx maps the columns by the db-tags to exported fields only
+	if settings.Unexported && !settings.TagsNoDb && !settings.TagsMastermindStructableOnly {
+		log.Errorf("warning: unexported fields cannot be scanned by sqlx, consider -tags-no-db\n")
+	}
+
+	log.Infof("running for %q...\r\n", settings.DbType)
+
+	tables, err := db.GetTables(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get tables: %w", err)
+	}
+
+	if settings.Routines {
+		routines, err := db.GetRoutines(ctx)
+		if err != nil {
+			return fmt.Errorf("could not get routines: %w", err)
+		}
+		tables = append(tables, routines...)
+	}
+
+	numTables := len(tables)
+	tables = filterTables(settings, log, tables)
+
+	log.Debugf("> number of tables: %v\r\n", len(tables))
+
+	// most likely a typo in the name of the schema or database
+	if len(tables) == 0 {
+		err = noTablesError(settings, numTables)
+		if settings.FailOnEmpty {
+			return err
+		}
+		log.Errorf("warning: %v\n", err)
+	}
+
+	if columnsAtOnce(settings) {
+		if err = getColumnsOfTables(ctx, db, tables); err != nil {
+			return fmt.Errorf("could not get columns of tables: %w", err)
+		}
+	} else if err = db.PrepareGetColumnsOfTableStmt(ctx); err != nil {
+		return fmt.Errorf("could not prepare the get-column-statement: %w", err)
+	}
+
+	// the columns the embedded struct is created from, the ones of the first
+	// table having all of them
+	embedColumns := embedReference(settings, tables)
+
+	var snap *snapshot
+	if !settings.Since.IsZero() {
+		if snap, err = loadSnapshot(settings, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	st := &stats{}
+
+	if err = processTables(ctx, settings, log, taggers, db, out, snap, embedColumns, tables, st); err != nil {
+		return err
+	}
+
+	if err = writeEmbedStructs(settings, log, taggers, db, out, snap, embedColumns, tables); err != nil {
+		return err
+	}
+
+	if err = writeNetTypes(settings, log, out, snap, tables); err != nil {
+		return err
+	}
+
+	if settings.FieldMeta {
+		if err = writeFieldMetaTypes(settings, log, out, snap, tables); err != nil {
+			return err
+		}
+	}
+
+	if settings.Registry {
+		if err = writeRegistries(settings, log, out, snap, tables, st); err != nil {
+			return err
+		}
+	}
+
+	if flusher, ok := out.(output.Flusher); ok {
+		err = flusher.Flush()
+		if errors.Is(err, output.ErrFileExists) {
+			log.Errorf("skipping structs: %v\n", err)
+			st.skipped += st.structs
+			st.structs, st.columns = 0, 0
+		} else if err != nil {
+			return fmt.Errorf("could not write structs: %w", err)
+		}
+	}
+
+	if snap != nil {
+		if err = snap.save(); err != nil {
+			return fmt.Errorf("could not write snapshot: %w", err)
+		}
+	}
+
+	destination := settings.OutputFilePath
+	if settings.OutputStdout {
+		destination = "stdout"
+	}
+	log.Infof("%s\n", st.summary(destination))
+	log.Infof("done!\n")
+
+	return nil
+}
+
+// columnsAtOnce returns true if the columns of all tables are got at once
+// before processing them. The embedded struct needs the columns of all tables
+// to find the ones it is created from.
+func columnsAtOnce(s *settings.Settings) bool {
+	return s.BatchColumns || len(s.Embed) > 0
+}
+
+// getColumnsOfTables gets the columns of all tables at once, the columns of
+// routines are known already.
+func getColumnsOfTables(ctx context.Context, db database.Database, tables []*database.Table) error {
+	nonRoutines := make([]*database.Table, 0, len(tables))
+	for _, table := range tables {
+		if !table.Routine {
+			nonRoutines = append(nonRoutines, table)
+		}
+	}
+	if len(nonRoutines) == 0 {
+		return nil
+	}
+	return db.GetColumnsOfTables(ctx, nonRoutines)
+}
+
+// noTablesError describes why there are no tables, either none were found at
+// all or all of the found ones got filtered.
+func noTablesError(settings *settings.Settings, numTables int) error {
+	if numTables > 0 {
+		return fmt.Errorf("%w matching -include/-exclude, all %d tables skipped", ErrNoTables, numTables)
+	}
+	schemas := settings.Schemas()
+	if len(schemas) == 1 {
+		return fmt.Errorf("%w in schema %q", ErrNoTables, schemas[0])
+	}
+	return fmt.Errorf("%w in schemas %q", ErrNoTables, schemas)
+}
+
+// filterTables keeps the tables included by the include and exclude patterns
+// of the settings.
+func filterTables(settings *settings.Settings, log *logger.Logger, tables []*database.Table) []*database.Table {
+	if len(settings.Include) == 0 && len(settings.Exclude) == 0 {
+		return tables
+	}
+
+	filtered := make([]*database.Table, 0, len(tables))
+	for _, table := range tables {
+		if settings.IsTableIncluded(table.Name) {
+			filtered = append(filtered, table)
+			continue
+		}
+		if settings.VVerbose {
+			log.Debugf("> skipping table %q\r\n", table.Name)
+		}
+	}
+
+	return filtered
+}
+
+// stats counts the outcome of processing the tables.
+type stats struct {
+	tables  int
+	structs int
+	columns int
+	skipped int
+
+	// generated holds the tables whose struct got written, either by this
+	// run or, unchanged since, by an earlier one
+	generated map[*database.Table]bool
+}
+
+// summary summarizes the stats in one line, eg. `Generated 42 structs (318
+// columns) in ./output`.
+func (st *stats) summary(outputFilePath string) string {
+	summary := fmt.Sprintf("Generated %d structs (%d columns) in %s", st.structs, st.columns, outputFilePath)
+	if st.skipped > 0 {
+		summary += fmt.Sprintf(", skipped %d of %d tables", st.skipped, st.tables)
+	}
+	return summary
+}
+
+// processTables processes the tables concurrently by the number of workers
+// given by the settings. The writes to the output are serialized. The first
+// error stops the processing of the remaining tables and gets returned after
+// all workers finished. The outcome of each table is counted by the stats.
+func processTables(ctx context.Context, settings *settings.Settings, log *logger.Logger, taggers tagger.Tagger, db database.Database, out output.Writer, snap *snapshot, embedColumns []database.Column, tables []*database.Table, st *stats) error {
+
+	workers := settings.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+
+	jobs := make(chan *database.Table)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for table := range jobs {
+				if failed() {
+					continue
+				}
+				if err := processTable(ctx, settings, log, taggers, db, out, snap, embedColumns, &mu, st, table); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, table := range tables {
+		jobs <- table
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return firstErr
+}
+
+// processTable creates and writes the struct of a single table. The given
+// mutex guards the write to the output and the stats. The struct is neither
+// created nor written if the metadata of the table is unchanged since the
+// time of the snapshot, if given.
+func processTable(ctx context.Context, settings *settings.Settings, log *logger.Logger, taggers tagger.Tagger, db database.Database, out output.Writer, snap *snapshot, embedColumns []database.Column, mu *sync.Mutex, st *stats, table *database.Table) (err error) {
+
+	written, unchanged := false, false
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		st.tables++
+		if written {
+			st.structs++
+			st.columns += countColumns(table)
+		} else if err == nil {
+			st.skipped++
+		}
+		if written || unchanged {
+			if st.generated == nil {
+				st.generated = map[*database.Table]bool{}
+			}
+			st.generated[table] = true
+		}
+	}()
+
+	log.Debugf("> processing table %q\r\n", table.Name)
+
+	// the columns of routines are known already, as are the ones of all
+	// tables got at once
+	if !table.Routine && !columnsAtOnce(settings) {
+		if err := db.GetColumnsOfTable(ctx, table); err != nil {
+			if !settings.Force {
+				return fmt.Errorf("could not get columns of table %q: %w", table.Name, err)
+			}
+			log.Errorf("could not get columns of table %q: %v\n", table.Name, err)
+			return nil
+		}
+	}
+
+	log.Debugf("\t> number of columns: %v\r\n", len(table.Columns))
+
+	if settings.ForeignKeys && !table.Routine {
+		if err := db.GetForeignKeysOfTable(ctx, table); err != nil {
+			if !settings.Force {
+				return fmt.Errorf("could not get foreign keys of table %q: %w", table.Name, err)
+			}
+			log.Errorf("could not get foreign keys of table %q: %v\n", table.Name, err)
+		}
+	}
+
+	pkgSettings, dir := packageOf(settings, table)
+
+	var hash string
+	if snap != nil {
+		if hash, err = snap.tableHash(table, embedColumns); err != nil {
+			return fmt.Errorf("could not hash table %q: %w", table.Name, err)
+		}
+		// the name of the struct fails again on creating it
+		if tableName, err := structName(pkgSettings, log, table); err == nil {
+			fileName := outputFileName(settings, tableName, structBaseName(settings, table), dir)
+			if snap.isUnchanged(fileName, hash) {
+				log.Debugf("\t> skipping unchanged table %q\r\n", table.Name)
+				unchanged = true
+				return nil
+			}
+		}
+	}
+
+	var tableName, content string
+	if settings.Ent {
+		tableName, content, err = createEntSchemaString(pkgSettings, log, db, table)
+	} else {
+		tableName, content, err = createTableStructString(pkgSettings, log, taggers, db, table, embedColumns, false)
+	}
+	if err != nil {
+		if !settings.Force {
+			return fmt.Errorf("could not create string for table %q: %w", table.Name, err)
+		}
+		log.Errorf("could not create string for table %q: %v\n", table.Name, err)
+		return nil
+	}
+
+	fileName := outputFileName(settings, tableName, structBaseName(settings, table), dir)
+
+	err = func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return out.Write(fileName, content)
+	}()
+
+	if errors.Is(err, output.ErrFileExists) {
+		log.Errorf("skipping table %q: %v\n", table.Name, err)
+		return nil
+	}
+
+	if err != nil {
+		var formatErr *output.FormatError
+		if errors.As(err, &formatErr) {
+			log.Debugf("> generated source of table %q:\n%s\n", table.Name, formatErr.Source)
+		}
+		if !settings.Force {
+			return fmt.Errorf("could not write struct for table %q: %w", table.Name, err)
+		}
+		log.Errorf("could not write struct for table %q: %v\n", table.Name, err)
+		return nil
+	}
+
+	written = true
+
+	if snap != nil {
+		snap.keep(fileName, hash)
+	}
+
+	return nil
+}
+
+// packageOf returns the settings of the package of the table along with its
+// sub directory, if any. A table mapped to a package is written into the sub
+// directory of the package, the others into the one of their schema if
+// enabled.
+func packageOf(s *settings.Settings, table *database.Table) (*settings.Settings, string) {
+	if pkg, ok := s.MappedPackage(table.Schema, table.Name); ok {
+		pkgSettings := *s
+		pkgSettings.PackageName = pkg
+		return &pkgSettings, pkg
+	}
+	if s.SubDirs {
+		return s, table.Schema
+	}
+	return s, ""
+}
+
+// outputFileName returns the name of the file to write the struct of the
+// given name to, in the given sub directory if any. The original format uses
+// the name the struct is based on as is.
+func outputFileName(settings *settings.Settings, structName, baseName, dir string) string {
+	fileName := camelCaseString(structName)
+	if settings.IsFileNameFormatSnakeCase() {
+		fileName = strcase.ToSnake(fileName)
+	}
+	if settings.IsFileNameFormatOriginal() {
+		fileName = baseName
+	}
+	if dir != "" {
+		fileName = path.Join(dir, fileName)
+	}
+	return fileName
+}
+
+// writeEmbedStructs writes the embedded struct once per package having a
+// table embedding it. Its fields are created from the columns of the first
+// table having all of them.
+func writeEmbedStructs(settings *settings.Settings, log *logger.Logger, taggers tagger.Tagger, db database.Database, out output.Writer, snap *snapshot, embedColumns []database.Column, tables []*database.Table) error {
+
+	written := map[string]bool{}
+
+	for _, table := range tables {
+		pkgSettings, dir := packageOf(settings, table)
+		if written[dir] || embeddedColumns(settings, embedColumns, table) == nil {
+			continue
+		}
+		written[dir] = true
+
+		structName, content, err := createEmbedStructString(pkgSettings, log, taggers, db, embedColumns)
+		if err != nil {
+			return fmt.Errorf("could not create string for embedded struct %q: %w", settings.EmbedName, err)
+		}
+
+		err = writeFile(out, snap, outputFileName(settings, structName, settings.EmbedName, dir), content)
+		if errors.Is(err, output.ErrFileExists) {
+			log.Errorf("skipping embedded struct %q: %v\n", settings.EmbedName, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not write embedded struct %q: %w", settings.EmbedName, err)
+		}
+	}
+
+	return nil
+}
+
+// writeNetTypes writes the types of the network address columns once per
+// package having any of them.
+func writeNetTypes(settings *settings.Settings, log *logger.Logger, out output.Writer, snap *snapshot, tables []*database.Table) error {
+
+	written := map[string]bool{}
+
+	for _, table := range tables {
+		pkgSettings, dir := packageOf(settings, table)
+		if written[dir] || !hasNetColumns(settings, table) {
+			continue
+		}
+		written[dir] = true
+
+		err := writeFile(out, snap, outputFileName(settings, netTypesName, "net_types", dir), createNetTypesString(pkgSettings))
+		if errors.Is(err, output.ErrFileExists) {
+			log.Errorf("skipping types %q: %v\n", netTypesName, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not write types %q: %w", netTypesName, err)
+		}
+	}
+
+	return nil
+}
+
+// hasNetColumns returns true if any column of the table is of the types of
+// the network address columns. Ent schemas do not use them.
+func hasNetColumns(settings *settings.Settings, table *database.Table) bool {
+	if settings.NetStrings || settings.Ent {
+		return false
+	}
+	for _, column := range table.Columns {
+		if _, isOverridden := settings.OverriddenType(table.Name, column.Name); isOverridden {
+			continue
+		}
+		if _, ok := netTypes[column.DataType]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFieldMetaTypes writes the type of the descriptors of the fields once
+// per package.
+func writeFieldMetaTypes(settings *settings.Settings, log *logger.Logger, out output.Writer, snap *snapshot, tables []*database.Table) error {
+
+	written := map[string]bool{}
+
+	for _, table := range tables {
+		pkgSettings, dir := packageOf(settings, table)
+		if written[dir] {
+			continue
+		}
+		written[dir] = true
+
+		err := writeFile(out, snap, outputFileName(settings, fieldMetaName, "field_meta", dir), createFieldMetaTypeString(pkgSettings))
+		if errors.Is(err, output.ErrFileExists) {
+			log.Errorf("skipping type %q: %v\n", fieldMetaName, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not write type %q: %w", fieldMetaName, err)
+		}
+	}
+
+	return nil
+}
+
+// writeRegistries writes the names of the tables whose struct got generated,
+// but not of the routines, once per package. The names of the tables of
+// multiple schemas sharing one package are qualified by their schema.
+func writeRegistries(settings *settings.Settings, log *logger.Logger, out output.Writer, snap *snapshot, tables []*database.Table, st *stats) error {
+
+	var packages []*database.Table
+	tableNames := map[string][]string{}
+
+	for _, table := range tables {
+		if table.Routine || !st.generated[table] {
+			continue
+		}
+		_, dir := packageOf(settings, table)
+		if _, ok := tableNames[dir]; !ok {
+			// the first table of the package stands for all of them
+			packages = append(packages, table)
+		}
+
+		name := table.Name
+		if table.Schema != "" && len(settings.Schemas()) > 1 && !settings.SubDirs {
+			name = table.Schema + "." + table.Name
+		}
+		tableNames[dir] = append(tableNames[dir], name)
+	}
+
+	for _, table := range packages {
+		pkgSettings, dir := packageOf(settings, table)
+		err := writeFile(out, snap, outputFileName(settings, registryName, "all_tables", dir), createRegistryString(pkgSettings, tableNames[dir]))
+		if errors.Is(err, output.ErrFileExists) {
+			log.Errorf("skipping variable %q: %v\n", registryName, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not write variable %q: %w", registryName, err)
+		}
+	}
+
+	return nil
+}
+
+// countColumns counts the distinct columns of the table, see ISSUE-4.
+func countColumns(table *database.Table) int {
+	columns := map[string]struct{}{}
+	for _, column := range table.Columns {
+		columns[column.Name] = struct{}{}
+	}
+	return len(columns)
+}
+
+type columnInfo struct {
+	isNullable bool
+	isTemporal bool
+
+	// imports holds the paths of further packages the type of the column
+	// depends on.
+	imports []string
+
+	// comment is written as trailing comment of the field of the column, it
+	// does not get merged.
+	comment string
+}
+
+// merge saves that we saw the given types of a column at least once.
+func (c *columnInfo) merge(other columnInfo) {
+	if !c.isTemporal {
+		c.isTemporal = other.isTemporal
+	}
+	if !c.isNullable {
+		c.isNullable = other.isNullable
+	}
+	for _, imp := range other.imports {
+		if !isStringInSlice(imp, c.imports) {
+			c.imports = append(c.imports, imp)
+		}
+	}
+}
+
+// structField holds the content of a field of the struct, including its
+// comments.
+type structField struct {
+	isPrimaryKey bool
+	content      string
+}
+
+// structBaseName returns the name of the struct of the table before it gets
+// formatted, including the prefix and suffix.
+func structBaseName(settings *settings.Settings, table *database.Table) string {
+
+	// the trimmed prefix is common to the tables, eg. `tbl_`, it is kept if
+	// nothing would remain of the name
+	name := table.Name
+	if trimmed := strings.TrimPrefix(name, settings.TrimPrefix); trimmed != "" {
+		name = trimmed
+	}
+
+	// the structs of the tables of all schemas share one package, unless they
+	// are written into sub directories per schema
+	if table.Schema != "" && len(settings.Schemas()) > 1 && !settings.SubDirs {
+		name = table.Schema + "_" + name
+	}
+
+	// the structs of routines must not collide with the ones of the tables
+	if table.Routine {
+		name += "_result"
+	}
+
+	return settings.Prefix + name + settings.Suffix
+}
+
+// structName returns the exported name of the struct of the table as a valid
+// identifier.
+func structName(settings *settings.Settings, log *logger.Logger, table *database.Table) (string, error) {
+
+	tableName := titleCase(structBaseName(settings, table))
+	// Replace any whitespace with underscores
+	tableName = strings.Map(replaceSpace, tableName)
+	if settings.IsOutputFormatCamelCase() {
+		tableName = camelCaseString(tableName)
+	}
+
+	// Check that the table name doesn't contain any invalid characters for Go variables
+	if !validVariableName(tableName) {
+		return "", fmt.Errorf("table name %q contains invalid characters", table.Name)
+	}
+
+	if !startsWithLetter(tableName) {
+		prefix := identifierPrefix(settings)
+		log.Debugf("\t>table %q doesn't start with a letter; prepending with %q\n", table.Name, prefix)
+		tableName = prefix + tableName
+	}
+
+	return sanitizeKeyword(tableName), nil
+}
+
+// createTableStructString creates the content of the file of the struct of the
+// table along with the name of the struct. The struct embedded by the others
+// carries no name of a table, eg. of the bun.BaseModel.
+func createTableStructString(settings *settings.Settings, log *logger.Logger, taggers tagger.Tagger, db database.Database, table *database.Table, embedColumns []database.Column, isEmbed bool) (string, string, error) {
+
+	var enumTypes strings.Builder
+
+	tableName, err := structName(settings, log, table)
+	if err != nil {
+		return "", "", err
+	}
+
+	// the names derived from the name of the struct stay exported, eg. the ones
+	// of the constants, as do the names of the getters derived from the fields
+	typeName := tableName
+	embedName := settings.EmbedName
+	if settings.Unexported {
+		typeName = unexportedName(tableName)
+		embedName = unexportedName(embedName)
+	}
+
+	columnInfo := columnInfo{}
+	columns := map[string]struct{}{}
+	usedColumnNames := map[string]struct{}{}
+
+	// a column is part of the primary key if any of its rows says so, see
+	// ISSUE-4 below
+	primaryKeys := map[string]bool{}
+	for _, column := range table.Columns {
+		if db.IsPrimaryKey(column) {
+			primaryKeys[column.Name] = true
+		}
+	}
+
+	var (
+		fields                 []structField
+		fieldNames             []string
+		exportedFieldNames     []string
+		fieldTypes             []string
+		fieldDefaults          []string
+		scanFieldNames         []string
+		exportedScanFieldNames []string
+		scanFieldTypes         []string
+		scanValidNames         []string
+		columnNames            []string
+		queryColumns           []database.Column
+	)
+
+	// the columns of the embedded struct are replaced by a reference to it
+	embedded := embeddedColumns(settings, embedColumns, table)
+	isEmbedWritten := false
+
+	for _, column := range table.Columns {
+		columnName, err := formatColumnName(settings, log, column.Name, table.Name)
+		if err != nil {
+			return "", "", err
+		}
+
+		// ISSUE-4: if columns are part of multiple constraints
+		// then the sql returns multiple rows per column name.
+		// Therefore, we check if we already added a column with
+		// that name to the struct, if so, skip.
+		if _, ok := columns[column.Name]; ok {
+			continue
+		}
+		columns[column.Name] = struct{}{}
+
+		// different columns can result in the same field name, eg. `user_id`
+		// and `USER_ID`, the later ones get a numeric suffix
+		columnName = uniqueColumnName(usedColumnNames, columnName)
+		usedColumnNames[columnName] = struct{}{}
+
+		fieldName := columnName
+		if settings.Unexported {
+			fieldName = unexportedName(columnName)
+		}
+
+		if settings.VVerbose {
+			log.Debugf("\t\t> %v\r\n", column.Name)
+		}
+
+		// the row of the column kept might be the one of another constraint
+		column.IsPrimaryKey = primaryKeys[column.Name]
+		queryColumns = append(queryColumns, column)
+
+		// the field gets promoted from the embedded struct
+		if embedded[column.Name] {
+			// the type as in the embedded struct, which is created from the
+			// same columns
+			embeddedType, _ := mapDbColumnTypeToGoType(settings, db, table.Name, column)
+			validName := ""
+			if _, isOverridden := settings.OverriddenType(table.Name, column.Name); settings.IsNullTypeZero() && db.IsNullable(column) && !isOverridden {
+				embeddedType = strings.TrimPrefix(embeddedType, "*")
+				validName = fieldName + "Valid"
+			}
+			scanFieldNames = append(scanFieldNames, fieldName)
+			exportedScanFieldNames = append(exportedScanFieldNames, columnName)
+			scanFieldTypes = append(scanFieldTypes, embeddedType)
+			scanValidNames = append(scanValidNames, validName)
+			columnNames = append(columnNames, column.Name)
+			if !isEmbedWritten {
+				fields = append(fields, structField{content: embedName + "\n"})
+				isEmbedWritten = true
+			}
+			continue
+		}
+
+		columnType, col := mapDbColumnTypeToGoType(settings, db, table.Name, column)
+
+		if settings.EnumConsts && db.IsEnum(column) {
+			enumTypeName := tableName + columnName
+			columnType = enumTypeName
+			if db.IsNullable(column) {
+				columnType = "*" + enumTypeName
+			}
+			col.isNullable = false
+			enumTypes.WriteString(createEnumTypeString(enumTypeName, column))
+		}
+
+		// zero values are valid unless the field of their validity says
+		// otherwise, overridden types are taken as is
+		_, isOverridden := settings.OverriddenType(table.Name, column.Name)
+		isZeroNullable := settings.IsNullTypeZero() && db.IsNullable(column) && !isOverridden
+		if isZeroNullable {
+			columnType = strings.TrimPrefix(columnType, "*")
+		}
+
+		columnInfo.merge(col)
+
+		var structFields strings.Builder
+
+		if settings.Comments && column.Comment.String != "" {
+			for _, line := range strings.Split(strings.TrimSpace(column.Comment.String), "\n") {
+				structFields.WriteString("// ")
+				structFields.WriteString(strings.TrimSpace(line))
+				structFields.WriteString("\n")
+			}
+		}
+
+		for _, fk := range table.ForeignKeys {
+			if fk.ColumnName != column.Name {
+				continue
+			}
+			structFields.WriteString("// FK -> ")
+			structFields.WriteString(fk.ReferencedTable)
+			if fk.ReferencedColumn != "" {
+				structFields.WriteString(".")
+				structFields.WriteString(fk.ReferencedColumn)
+			}
+			structFields.WriteString("\n")
+		}
+
+		structFields.WriteString(fieldName)
+		structFields.WriteString(" ")
+		structFields.WriteString(columnType)
+		column.GoType = columnType
+		if tags := taggers.GenerateTag(db, column); tags != "" {
+			structFields.WriteString(" ")
+			structFields.WriteString(tags)
+		}
+
+		// a trailing comment on the same line stays attached to the field
+		var comments []string
+		if col.comment != "" {
+			comments = append(comments, col.comment)
+		}
+		if settings.Defaults && column.DefaultValue.Valid {
+			comments = append(comments, "default: "+strings.Join(strings.Fields(column.DefaultValue.String), " "))
+		}
+		if keys := keysComment(column); keys != "" {
+			comments = append(comments, keys)
+		}
+		if len(comments) > 0 {
+			structFields.WriteString(" // ")
+			structFields.WriteString(strings.Join(comments, "; "))
+		}
+		structFields.WriteString("\n")
+
+		fieldDefault := column.DefaultValue.String
+		validName := ""
+		if isZeroNullable {
+			validName = uniqueColumnName(usedColumnNames, columnName+"Valid")
+			usedColumnNames[validName] = struct{}{}
+			if settings.Unexported {
+				validName = unexportedName(validName)
+			}
+			structFields.WriteString(validName)
+			structFields.WriteString(" bool")
+			if tags := ignoredFieldTags(settings); tags != "" {
+				structFields.WriteString(" ")
+				structFields.WriteString(tags)
+			}
+			structFields.WriteString("\n")
+			// a default would not be valid without its field of validity
+			fieldDefault = ""
+		}
+
+		fieldNames = append(fieldNames, fieldName)
+		exportedFieldNames = append(exportedFieldNames, columnName)
+		fieldTypes = append(fieldTypes, columnType)
+		fieldDefaults = append(fieldDefaults, fieldDefault)
+		scanFieldNames = append(scanFieldNames, fieldName)
+		exportedScanFieldNames = append(exportedScanFieldNames, columnName)
+		scanFieldTypes = append(scanFieldTypes, columnType)
+		scanValidNames = append(scanValidNames, validName)
+		columnNames = append(columnNames, column.Name)
+		fields = append(fields, structField{
+			isPrimaryKey: primaryKeys[column.Name],
+			content:      structFields.String(),
+		})
+	}
+
+	// the methods must not be named like any field, the unexported fields never
+	// are, but the promoted ones of the embedded struct are
+	methodFieldNames := map[string]struct{}{}
+	if !settings.Unexported {
+		for name := range usedColumnNames {
+			methodFieldNames[name] = struct{}{}
+		}
+		if isEmbedWritten {
+			methodFieldNames[embedName] = struct{}{}
+		}
+	}
+
+	if settings.IsTableConstMethod() && !table.Routine {
+		if err := checkMethodName(methodFieldNames, "TableName", "-tableconst method"); err != nil {
+			return "", "", err
+		}
+	}
+
+	if settings.PKFirst {
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fields[i].isPrimaryKey && !fields[j].isPrimaryKey
+		})
+	}
+
+	var structFields strings.Builder
+
+	// bun expects the name of the table at the embedded model in front
+	isBunModel := settings.TagsBun && !table.Routine && !isEmbed
+	if isBunModel {
+		structFields.WriteString("bun.BaseModel `bun:\"table:")
+		structFields.WriteString(table.Name)
+		structFields.WriteString("\"`\n\n")
+		if !isStringInSlice("github.com/uptrace/bun", columnInfo.imports) {
+			columnInfo.imports = append(columnInfo.imports, "github.com/uptrace/bun")
+		}
+	}
+
+	for _, field := range fields {
+		structFields.WriteString(field.content)
+	}
+
+	if settings.IsMastermindStructableRecorder {
+		structFields.WriteString("\nstructable.Recorder\n")
+	}
+
+	var fileContent strings.Builder
+
+	// write build constraint, separated by a blank line from the package clause
+	if settings.BuildTags != "" {
+		fileContent.WriteString(createBuildConstraintString(settings))
+	}
+
+	// write header infos
+	fileContent.WriteString("package ")
+	fileContent.WriteString(settings.PackageName)
+	fileContent.WriteString("\n\n")
+
+	// write imports
+	generateImports(&fileContent, settings, columnInfo)
+
+	// write summary of the table as doc comment of the struct
+	if settings.DDLComment {
+		fileContent.WriteString(createDDLCommentString(db, table))
+	}
+
+	// write struct with fields
+	fileContent.WriteString("type ")
+	fileContent.WriteString(typeName)
+	fileContent.WriteString(" struct {\n")
+	fileContent.WriteString(structFields.String())
+	fileContent.WriteString("}")
+
+	// write constructor initializing the fields with the defaults
+	if settings.Constructor {
+		fileContent.WriteString(createConstructorString(tableName, typeName, fieldNames, fieldTypes, fieldDefaults))
+	}
+
+	// write sample of the struct as a starting point of test fixtures
+	if settings.Fixtures {
+		fileContent.WriteString(createFixtureString(tableName, typeName, fieldNames, fieldTypes))
+	}
+
+	// write names of the columns referenced by the fields of the struct
+	if settings.ColumnMap {
+		fileContent.WriteString(createColumnMapString(tableName, exportedScanFieldNames, columnNames))
+	}
+
+	// write statements of the table, routines are no tables
+	if settings.SQL && !table.Routine {
+		fileContent.WriteString(createSQLString(settings, db, tableName, table.Schema, table.Name, queryColumns))
+	}
+
+	// the fields of validity of null type zero are no destinations of a scan,
+	// they get set by scanning the row instead
+	isValidScanned := false
+	for _, validName := range scanValidNames {
+		if validName != "" {
+			isValidScanned = !isEmbed
+			break
+		}
+	}
+	if isValidScanned {
+		if err := checkMethodName(methodFieldNames, "ScanRow", "-null zero"); err != nil {
+			return "", "", err
+		}
+		fileContent.WriteString(createScanRowString(tableName, typeName, scanFieldNames, exportedScanFieldNames, scanFieldTypes, scanValidNames))
+	}
+
+	// write scan helper with the fields in the order of the columns
+	if settings.ScanHelper && !isValidScanned {
+		if err := checkMethodName(methodFieldNames, "ScanDest", "-scanhelper"); err != nil {
+			return "", "", err
+		}
+		receiver := strings.ToLower(string([]rune(tableName)[0]))
+		fileContent.WriteString("\n\nfunc (")
+		fileContent.WriteString(receiver)
+		fileContent.WriteString(" *")
+		fileContent.WriteString(typeName)
+		fileContent.WriteString(") ScanDest() []interface{} {\nreturn []interface{}{\n")
+		for _, fieldName := range scanFieldNames {
+			fileContent.WriteString("&")
+			fileContent.WriteString(receiver)
+			fileContent.WriteString(".")
+			fileContent.WriteString(fieldName)
+			fileContent.WriteString(",\n")
+		}
+		fileContent.WriteString("}\n}")
+	}
+
+	// write getters with the fields in the order of the columns
+	if settings.Getters {
+		for _, fieldName := range exportedFieldNames {
+			if err := checkMethodName(methodFieldNames, "Get"+fieldName, "-getters"); err != nil {
+				return "", "", err
+			}
+		}
+		fileContent.WriteString(createGettersString(tableName, typeName, fieldNames, exportedFieldNames, fieldTypes))
+	}
+
+	// write descriptors of the fields in the order of the columns
+	if settings.FieldMeta {
+		if err := checkMethodName(methodFieldNames, "Fields", "-fieldmeta"); err != nil {
+			return "", "", err
+		}
+		fileContent.WriteString(createFieldMetaString(typeName, scanFieldNames, columnNames, scanFieldTypes))
+	}
+
+	// write name of the table, routines are no tables
+	if settings.IsTableConstMethod() && !table.Routine {
+		fileContent.WriteString("\n\nfunc (")
+		fileContent.WriteString(typeName)
+		fileContent.WriteString(") TableName() string {\nreturn ")
+		fileContent.WriteString(strconv.Quote(table.Name))
+		fileContent.WriteString("\n}")
+	}
+	if settings.IsTableConstConst() && !table.Routine {
+		fileContent.WriteString("\n\nconst TableName")
+		fileContent.WriteString(tableName)
+		fileContent.WriteString(" = ")
+		fileContent.WriteString(strconv.Quote(table.Name))
+	}
+
+	// write types of the enums
+	fileContent.WriteString(enumTypes.String())
+
+	return tableName, fileContent.String(), nil
+}
+
+// checkMethodName fails if a field of the struct is named like the method
+// generated by the given flag, eg. the field TableName of the column
+// `table_name` along with the method TableName(), which go does not compile.
+func checkMethodName(fieldNames map[string]struct{}, method, flag string) error {
+	if _, ok := fieldNames[method]; ok {
+		return fmt.Errorf("field %q collides with the method %s() of %s, rename it by -rename", method, method, flag)
+	}
+	return nil
+}
+
+// createScanRowString creates a method ScanRow() scanning a row into the
+// fields in the order of the columns. The nullable columns of null type zero
+// are scanned into pointers first, a NULL sets the zero value of the field
+// and false to the field of its validity.
+func createScanRowString(tableName, typeName string, fieldNames, exportedFieldNames, fieldTypes, validNames []string) string {
+
+	var scanRow strings.Builder
+
+	receiver := strings.ToLower(string([]rune(tableName)[0]))
+
+	// the variables are named after the fields, the prefix keeps them apart
+	// from the receiver, the row and the error
+	nullableNames := make([]string, len(fieldNames))
+	for i, fieldName := range exportedFieldNames {
+		if validNames[i] != "" {
+			nullableNames[i] = "nullable" + fieldName
+		}
+	}
+
+	scanRow.WriteString("\n\nfunc (")
+	scanRow.WriteString(receiver)
+	scanRow.WriteString(" *")
+	scanRow.WriteString(typeName)
+	scanRow.WriteString(") ScanRow(row interface{ Scan(dest ...interface{}) error }) error {\n")
+	for i, nullableName := range nullableNames {
+		if nullableName == "" {
+			continue
+		}
+		scanRow.WriteString("var ")
+		scanRow.WriteString(nullableName)
+		scanRow.WriteString(" *")
+		scanRow.WriteString(fieldTypes[i])
+		scanRow.WriteString("\n")
+	}
+	scanRow.WriteString("if err := row.Scan(")
+	for i, fieldName := range fieldNames {
+		if i > 0 {
+			scanRow.WriteString(", ")
+		}
+		scanRow.WriteString("&")
+		if nullableNames[i] != "" {
+			scanRow.WriteString(nullableNames[i])
+			continue
+		}
+		scanRow.WriteString(receiver)
+		scanRow.WriteString(".")
+		scanRow.WriteString(fieldName)
+	}
+	scanRow.WriteString("); err != nil {\nreturn err\n}\n")
+	for i, nullableName := range nullableNames {
+		if nullableName == "" {
+			continue
+		}
+		field := receiver + "." + fieldNames[i] + ", " + receiver + "." + validNames[i]
+		scanRow.WriteString("if ")
+		scanRow.WriteString(nullableName)
+		scanRow.WriteString(" != nil {\n")
+		scanRow.WriteString(field)
+		scanRow.WriteString(" = *")
+		scanRow.WriteString(nullableName)
+		scanRow.WriteString(", true\n} else {\n")
+		scanRow.WriteString(field)
+		scanRow.WriteString(" = ")
+		scanRow.WriteString(zeroLiteral(fieldTypes[i]))
+		scanRow.WriteString(", false\n}\n")
+	}
+	scanRow.WriteString("return nil\n}")
+
+	return scanRow.String()
+}
+
+// zeroLiteral returns a go literal of the zero value of the given type.
+func zeroLiteral(goType string) string {
+	switch goType {
+	case "string":
+		return `""`
+	case "bool":
+		return "false"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return "0"
+	case "time.Time":
+		return "time.Time{}"
+	}
+	if strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") || strings.HasPrefix(goType, "*") {
+		return "nil"
+	}
+	return "*new(" + goType + ")"
+}
+
+// ignoredFieldTags creates the tags of a field which is no column, eg. the
+// validity of a nullable column of null type zero, so the field is ignored
+// by the enabled libraries.
+func ignoredFieldTags(settings *settings.Settings) string {
+	var tags []string
+	if !settings.TagsNoDb && !settings.TagsMastermindStructableOnly {
+		tags = append(tags, `db:"-"`)
+	}
+	if settings.TagsGorm {
+		tags = append(tags, `gorm:"-"`)
+	}
+	if settings.TagsBun {
+		tags = append(tags, `bun:"-"`)
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	return "`" + strings.Join(tags, " ") + "`"
+}
+
+// embedReference returns the columns of the first table having all columns
+// of the embedded struct, in the order given by the settings.
+func embedReference(s *settings.Settings, tables []*database.Table) []database.Column {
+	for _, table := range tables {
+		columns := columnsByName(table)
+		reference := make([]database.Column, 0, len(s.Embed))
+		for _, name := range s.Embed {
+			column, ok := columns[name]
+			if !ok {
+				break
+			}
+			reference = append(reference, column)
+		}
+		if len(reference) == len(s.Embed) {
+			return reference
+		}
+	}
+	return nil
+}
+
+// columnsByName returns the columns of the table by their names, the first
+// row of a column is kept if it is part of multiple constraints.
+func columnsByName(table *database.Table) map[string]database.Column {
+	columns := make(map[string]database.Column, len(table.Columns))
+	for _, column := range table.Columns {
+		if _, ok := columns[column.Name]; !ok {
+			columns[column.Name] = column
+		}
+	}
+	return columns
+}
+
+// embeddedColumns returns the columns of the table to be replaced by the
+// embedded struct. These are none unless the table has all of them, of the
+// same type and nullability as the given ones the embedded struct is created
+// from.
+func embeddedColumns(settings *settings.Settings, embedColumns []database.Column, table *database.Table) map[string]bool {
+	if len(settings.Embed) == 0 || len(embedColumns) == 0 {
+		return nil
+	}
+
+	columns := columnsByName(table)
+
+	embedded := map[string]bool{}
+	for _, reference := range embedColumns {
+		column, ok := columns[reference.Name]
+		if !ok || column.DataType != reference.DataType || column.IsNullable != reference.IsNullable {
+			return nil
+		}
+		embedded[reference.Name] = true
+	}
+
+	return embedded
+}
+
+// createEmbedStructString creates the struct to be embedded from the given
+// columns. Only the plain struct gets created, without any methods or the
+// name of the table.
+func createEmbedStructString(s *settings.Settings, log *logger.Logger, taggers tagger.Tagger, db database.Database, embedColumns []database.Column) (string, string, error) {
+
+	embedTable := &database.Table{
+		Name:    s.EmbedName,
+		Columns: embedColumns,
+	}
+
+	embedSettings := *s
+	embedSettings.Embed = nil
+	embedSettings.Prefix = ""
+	embedSettings.Suffix = ""
+	embedSettings.TableConst = settings.TableConstNone
+	embedSettings.ScanHelper = false
+	embedSettings.Getters = false
+	embedSettings.DDLComment = false
+	embedSettings.IsMastermindStructableRecorder = false
+	embedSettings.ColumnMap = false
+	embedSettings.Constructor = false
+	embedSettings.Fixtures = false
+	embedSettings.SQL = false
+	embedSettings.FieldMeta = false
+
+	return createTableStructString(&embedSettings, log, taggers, db, embedTable, nil, true)
+}
+
+// createColumnMapString creates a variable holding the name of the column of
+// each field, eg. `UsersColumns.ID` is `"id"`. The field names are unique
+// already.
+func createColumnMapString(tableName string, fieldNames, columnNames []string) string {
+
+	var columnMap strings.Builder
+
+	columnMap.WriteString("\n\nvar ")
+	columnMap.WriteString(tableName)
+	columnMap.WriteString("Columns = struct {\n")
+	for _, fieldName := range fieldNames {
+		columnMap.WriteString(fieldName)
+		columnMap.WriteString(" string\n")
+	}
+	columnMap.WriteString("}{\n")
+	for i, fieldName := range fieldNames {
+		columnMap.WriteString(fieldName)
+		columnMap.WriteString(": ")
+		columnMap.WriteString(strconv.Quote(columnNames[i]))
+		columnMap.WriteString(",\n")
+	}
+	columnMap.WriteString("}")
+
+	return columnMap.String()
+}
+
+// createFieldMetaTypeString creates the content of the file of the type of
+// the descriptors of the fields, shared by all structs of the package.
+func createFieldMetaTypeString(settings *settings.Settings) string {
+
+	var fileContent strings.Builder
+
+	if settings.BuildTags != "" {
+		fileContent.WriteString(createBuildConstraintString(settings))
+	}
+
+	fileContent.WriteString("package ")
+	fileContent.WriteString(settings.PackageName)
+	fileContent.WriteString("\n\n// ")
+	fileContent.WriteString(fieldMetaName)
+	fileContent.WriteString(" describes a field of a struct along with its column.\ntype ")
+	fileContent.WriteString(fieldMetaName)
+	fileContent.WriteString(" struct {\nName string\nColumn string\nType string\n}")
+
+	return fileContent.String()
+}
+
+// createNetTypesString creates the content of the file of the types of the
+// network address columns.
+func createNetTypesString(settings *settings.Settings) string {
+
+	var fileContent strings.Builder
+
+	if settings.BuildTags != "" {
+		fileContent.WriteString(createBuildConstraintString(settings))
+	}
+
+	fileContent.WriteString("package ")
+	fileContent.WriteString(settings.PackageName)
+	fileContent.WriteString("\n\n")
+	fileContent.WriteString(netTypesSource)
+
+	return fileContent.String()
+}
+
+// createRegistryString creates the content of the file of the variable of the
+// names of the given tables.
+func createRegistryString(settings *settings.Settings, tableNames []string) string {
+
+	var fileContent strings.Builder
+
+	if settings.BuildTags != "" {
+		fileContent.WriteString(createBuildConstraintString(settings))
+	}
+
+	fileContent.WriteString("package ")
+	fileContent.WriteString(settings.PackageName)
+	fileContent.WriteString("\n\n// ")
+	fileContent.WriteString(registryName)
+	fileContent.WriteString(" holds the names of all tables.\nvar ")
+	fileContent.WriteString(registryName)
+	fileContent.WriteString(" = []string{\n")
+	for _, tableName := range tableNames {
+		fileContent.WriteString(strconv.Quote(tableName))
+		fileContent.WriteString(",\n")
+	}
+	fileContent.WriteString("}")
+
+	return fileContent.String()
+}
+
+// createFieldMetaString creates a method Fields returning the descriptors of
+// the fields of the struct in the order of the columns, eg. for building
+// queries without reflection.
+func createFieldMetaString(typeName string, fieldNames, columnNames, fieldTypes []string) string {
+
+	var fieldMeta strings.Builder
+
+	fieldMeta.WriteString("\n\nfunc (")
+	fieldMeta.WriteString(typeName)
+	fieldMeta.WriteString(") Fields() []")
+	fieldMeta.WriteString(fieldMetaName)
+	fieldMeta.WriteString(" {\nreturn []")
+	fieldMeta.WriteString(fieldMetaName)
+	fieldMeta.WriteString("{\n")
+	for i, fieldName := range fieldNames {
+		fieldMeta.WriteString("{Name: ")
+		fieldMeta.WriteString(strconv.Quote(fieldName))
+		fieldMeta.WriteString(", Column: ")
+		fieldMeta.WriteString(strconv.Quote(columnNames[i]))
+		fieldMeta.WriteString(", Type: ")
+		fieldMeta.WriteString(strconv.Quote(fieldTypes[i]))
+		fieldMeta.WriteString("},\n")
+	}
+	fieldMeta.WriteString("}\n}")
+
+	return fieldMeta.String()
+}
+
+// createConstructorString creates a function New<Struct>() returning a new
+// struct, or new<Struct>() for an unexported struct of the given type name.
+// Fields are initialized with the default of their column if it is a literal
+// of the type of the field, other defaults like `now()` are left to the
+// database.
+func createConstructorString(tableName, typeName string, fieldNames, fieldTypes, fieldDefaults []string) string {
+
+	var constructor strings.Builder
+
+	prefix := "New"
+	if !token.IsExported(typeName) {
+		prefix = "new"
+	}
+
+	constructor.WriteString("\n\nfunc ")
+	constructor.WriteString(prefix)
+	constructor.WriteString(tableName)
+	constructor.WriteString("() *")
+	constructor.WriteString(typeName)
+	constructor.WriteString(" {\nreturn &")
+	constructor.WriteString(typeName)
+	constructor.WriteString("{")
+	isFieldWritten := false
+	for i, fieldName := range fieldNames {
+		literal, ok := defaultLiteral(fieldTypes[i], fieldDefaults[i])
+		if !ok {
+			continue
+		}
+		if !isFieldWritten {
+			constructor.WriteString("\n")
+			isFieldWritten = true
+		}
+		constructor.WriteString(fieldName)
+		constructor.WriteString(": ")
+		constructor.WriteString(literal)
+		constructor.WriteString(",\n")
+	}
+	constructor.WriteString("}\n}")
+
+	return constructor.String()
+}
+
+// createFixtureString creates a function Sample<Struct>() returning the struct,
+// or sample<Struct>() for an unexported struct of the given type name,
+// populated with sample values by the types of the fields. Fields of other
+// types, eg. pointers, keep their zero value.
+func createFixtureString(tableName, typeName string, fieldNames, fieldTypes []string) string {
+
+	var fixture strings.Builder
+
+	prefix := "Sample"
+	if !token.IsExported(typeName) {
+		prefix = "sample"
+	}
+
+	fixture.WriteString("\n\nfunc ")
+	fixture.WriteString(prefix)
+	fixture.WriteString(tableName)
+	fixture.WriteString("() ")
+	fixture.WriteString(typeName)
+	fixture.WriteString(" {\nreturn ")
+	fixture.WriteString(typeName)
+	fixture.WriteString("{")
+	isFieldWritten := false
+	for i, fieldName := range fieldNames {
+		literal, ok := sampleLiteral(fieldTypes[i])
+		if !ok {
+			continue
+		}
+		if !isFieldWritten {
+			fixture.WriteString("\n")
+			isFieldWritten = true
+		}
+		fixture.WriteString(fieldName)
+		fixture.WriteString(": ")
+		fixture.WriteString(literal)
+		fixture.WriteString(",\n")
+	}
+	fixture.WriteString("}\n}")
+
+	return fixture.String()
+}
+
+// sampleLiteral returns a go literal of a sample value of the given type. It
+// fails for types without an obvious sample value, eg. pointers or types of
+// other packages than time and database/sql.
+func sampleLiteral(goType string) (string, bool) {
+	switch goType {
+	case "string":
+		return `""`, true
+	case "bool":
+		return "false", true
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return "0", true
+	case "time.Time":
+		return "time.Now()", true
+	}
+	// the sql.Null* types are NULL by default
+	if strings.HasPrefix(goType, "sql.Null") {
+		return goType + "{}", true
+	}
+	return "", false
+}
+
+// defaultLiteral converts the default value of a column to a go literal of
+// the given type. It fails for non-literal defaults, eg. function calls, for
+// the zero value of the type and for types other than the builtin ones, eg.
+// nullable types.
+func defaultLiteral(goType, defaultValue string) (string, bool) {
+
+	value := strings.TrimSpace(defaultValue)
+	// pg: 'abc'::character varying
+	if i := strings.Index(value, "::"); i > 0 {
+		value = value[:i]
+	}
+	// sqlite: (0)
+	for len(value) > 1 && value[0] == '(' && value[len(value)-1] == ')' {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
 
-	taggers = tagger.NewTaggers(settings)
+	isQuoted := len(value) > 1 && value[0] == '\'' && value[len(value)-1] == '\''
 
-	fmt.Printf("running for %q...\r\n", settings.DbType)
+	var literal string
 
-	tables, err := db.GetTables()
-	if err != nil {
-		return fmt.Errorf("could not get tables: %w", err)
+	switch goType {
+	case "string":
+		if !isQuoted {
+			return "", false
+		}
+		literal = strconv.Quote(strings.ReplaceAll(value[1:len(value)-1], "''", "'"))
+		if literal == `""` {
+			return "", false
+		}
+		return literal, true
+	case "bool":
+		switch strings.ToLower(strings.Trim(value, "'")) {
+		case "true", "t", "1":
+			return "true", true
+		}
+		return "", false
+	case "int", "int8", "int16", "int32", "int64":
+		i, err := strconv.ParseInt(strings.Trim(value, "'"), 10, 64)
+		if err != nil || i == 0 {
+			return "", false
+		}
+		literal = strconv.FormatInt(i, 10)
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		u, err := strconv.ParseUint(strings.Trim(value, "'"), 10, 64)
+		if err != nil || u == 0 {
+			return "", false
+		}
+		literal = strconv.FormatUint(u, 10)
+	case "float32", "float64":
+		f, err := strconv.ParseFloat(strings.Trim(value, "'"), 64)
+		if err != nil || f == 0 {
+			return "", false
+		}
+		literal = strconv.FormatFloat(f, 'g', -1, 64)
+	default:
+		return "", false
 	}
 
-	if settings.Verbose {
-		fmt.Printf("> number of tables: %v\r\n", len(tables))
-	}
+	return literal, true
+}
 
-	if err = db.PrepareGetColumnsOfTableStmt(); err != nil {
-		return fmt.Errorf("could not prepare the get-column-statement: %w", err)
-	}
+// createSQLString creates constants holding the statements to select all rows
+// of the table, to insert a row and to update a row by its primary key. Auto
+// increment columns are left to the database on insert, the update is omitted
+// without a primary key. The placeholders depend on the database type.
+func createSQLString(settings *settings.Settings, db database.Database, structName, schema, tableName string, columns []database.Column) string {
 
-	for _, table := range tables {
+	var (
+		selectColumns []string
+		insertColumns []string
+		setColumns    []string
+		keyColumns    []string
+	)
 
-		if settings.Verbose {
-			fmt.Printf("> processing table %q\r\n", table.Name)
+	for _, column := range columns {
+		name := quoteIdentifier(settings, column.Name)
+		selectColumns = append(selectColumns, name)
+		if !db.IsAutoIncrement(column) {
+			insertColumns = append(insertColumns, name)
 		}
-
-		if err = db.GetColumnsOfTable(table); err != nil {
-			if !settings.Force {
-				return fmt.Errorf("could not get columns of table %q: %w", table.Name, err)
-			}
-			fmt.Printf("could not get columns of table %q: %v\n", table.Name, err)
-			continue
+		if column.IsPrimaryKey {
+			keyColumns = append(keyColumns, name)
+		} else {
+			setColumns = append(setColumns, name)
 		}
+	}
 
-		if settings.Verbose {
-			fmt.Printf("\t> number of columns: %v\r\n", len(table.Columns))
-		}
+	// the schema tells apart the tables of the same name of multiple schemas,
+	// the search path of the connection would resolve them otherwise
+	table := quoteIdentifier(settings, tableName)
+	if schema != "" {
+		table = quoteIdentifier(settings, schema) + "." + table
+	}
 
-		tableName, content, err := createTableStructString(settings, db, table)
+	var statements strings.Builder
 
-		if err != nil {
-			if !settings.Force {
-				return fmt.Errorf("could not create string for table %q: %w", table.Name, err)
-			}
-			fmt.Printf("could not create string for table %q: %v\n", table.Name, err)
-			continue
-		}
+	statements.WriteString("\n\nconst (\n")
 
-		fileName := camelCaseString(tableName)
-		if settings.IsFileNameFormatSnakeCase() {
-			fileName = strcase.ToSnake(fileName)
-		}
+	statements.WriteString(structName)
+	statements.WriteString("SelectAll = ")
+	statements.WriteString(strconv.Quote("SELECT " + strings.Join(selectColumns, ", ") + " FROM " + table))
+	statements.WriteString("\n")
 
-		err = out.Write(fileName, content)
-		if err != nil {
-			if !settings.Force {
-				return fmt.Errorf("could not write struct for table %q: %w", table.Name, err)
-			}
-			fmt.Printf("could not write struct for table %q: %v\n", table.Name, err)
+	statements.WriteString(structName)
+	statements.WriteString("Insert = ")
+	statements.WriteString(strconv.Quote(insertStatement(settings, table, insertColumns)))
+	statements.WriteString("\n")
+
+	if len(keyColumns) > 0 && len(setColumns) > 0 {
+		assignments := make([]string, len(setColumns))
+		for i, name := range setColumns {
+			assignments[i] = name + " = " + placeholder(settings, i+1)
 		}
+		conditions := make([]string, len(keyColumns))
+		for i, name := range keyColumns {
+			conditions[i] = name + " = " + placeholder(settings, len(setColumns)+i+1)
+		}
+		statements.WriteString(structName)
+		statements.WriteString("Update = ")
+		statements.WriteString(strconv.Quote("UPDATE " + table + " SET " + strings.Join(assignments, ", ") + " WHERE " + strings.Join(conditions, " AND ")))
+		statements.WriteString("\n")
 	}
 
-	fmt.Println("done!")
+	statements.WriteString(")")
 
-	return nil
+	return statements.String()
 }
 
-type columnInfo struct {
-	isNullable bool
-	isTemporal bool
+// insertStatement returns the statement inserting the given columns into the
+// table. Without any columns, eg. if all columns are auto increment columns,
+// the defaults get inserted; only MySQL takes empty lists of columns and values
+// for it.
+func insertStatement(s *settings.Settings, table string, columns []string) string {
+	if len(columns) == 0 && s.DbType != settings.DBTypeMySQL {
+		return "INSERT INTO " + table + " DEFAULT VALUES"
+	}
+
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = placeholder(s, i+1)
+	}
+
+	return "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
 }
 
-func (c columnInfo) isNullableOrTemporal() bool {
-	return c.isNullable || c.isTemporal
+// placeholder returns the n-th placeholder, starting at one, of a statement
+// of the database type, eg. `$1` for Postgresql or `?` for MySQL.
+func placeholder(s *settings.Settings, n int) string {
+	switch s.DbType {
+	case settings.DBTypeMySQL, settings.DBTypeSQLite:
+		return "?"
+	}
+	return "$" + strconv.Itoa(n)
 }
 
-func createTableStructString(settings *settings.Settings, db database.Database, table *database.Table) (string, string, error) {
+// quoteIdentifier quotes the name of a table or column unless it consists of
+// lower case letters, digits and underscores only. MySQL quotes by backticks,
+// the others by double quotes. Reserved words are not detected, they are
+// unusual names of tables or columns anyway.
+func quoteIdentifier(s *settings.Settings, name string) string {
+	isPlain := name != "" && !unicode.IsDigit(rune(name[0]))
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_') {
+			isPlain = false
+			break
+		}
+	}
+	if isPlain {
+		return name
+	}
+	if s.DbType == settings.DBTypeMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
 
-	var structFields strings.Builder
-	tableName := caser.String(settings.Prefix + table.Name + settings.Suffix)
-	// Replace any whitespace with underscores
-	tableName = strings.Map(replaceSpace, tableName)
-	if settings.IsOutputFormatCamelCase() {
-		tableName = camelCaseString(tableName)
+// createBuildConstraintString creates the //go:build line of the build tags
+// along with the legacy // +build lines for older versions of go.
+func createBuildConstraintString(settings *settings.Settings) string {
+	expr, err := settings.BuildConstraint()
+	if err != nil {
+		// already checked by the verification of the settings
+		return ""
 	}
 
-	// Check that the table name doesn't contain any invalid characters for Go variables
-	if !validVariableName(tableName) {
-		return "", "", fmt.Errorf("table name %q contains invalid characters", table.Name)
+	var buildConstraint strings.Builder
+
+	buildConstraint.WriteString("//go:build ")
+	buildConstraint.WriteString(expr.String())
+	buildConstraint.WriteString("\n")
+
+	plusBuildLines, err := constraint.PlusBuildLines(expr)
+	if err == nil {
+		for _, line := range plusBuildLines {
+			buildConstraint.WriteString(line)
+			buildConstraint.WriteString("\n")
+		}
 	}
+	buildConstraint.WriteString("\n")
+
+	return buildConstraint.String()
+}
+
+// createDDLCommentString creates a comment summarizing the table similar to
+// its CREATE TABLE statement. It is not meant to be executable.
+func createDDLCommentString(db database.Database, table *database.Table) string {
+
+	var (
+		definitions []string
+		primaryKeys []string
+	)
 
-	columnInfo := columnInfo{}
 	columns := map[string]struct{}{}
 
 	for _, column := range table.Columns {
-		columnName, err := formatColumnName(settings, column.Name, table.Name)
-		if err != nil {
-			return "", "", err
+		// see ISSUE-4
+		if _, ok := columns[column.Name]; ok {
+			if db.IsPrimaryKey(column) && !isStringInSlice(column.Name, primaryKeys) {
+				primaryKeys = append(primaryKeys, column.Name)
+			}
+			continue
 		}
+		columns[column.Name] = struct{}{}
 
-		// ISSUE-4: if columns are part of multiple constraints
-		// then the sql returns multiple rows per column name.
-		// Therefore, we check if we already added a column with
-		// that name to the struct, if so, skip.
-		if _, ok := columns[columnName]; ok {
-			continue
+		definition := column.Name + " " + column.DataType
+		if column.ColumnType != "" {
+			definition = column.Name + " " + column.ColumnType
+		} else if column.CharacterMaximumLength.Valid {
+			definition += "(" + strconv.FormatInt(column.CharacterMaximumLength.Int64, 10) + ")"
+		}
+		if !db.IsNullable(column) {
+			definition += " NOT NULL"
 		}
-		columns[columnName] = struct{}{}
+		if column.DefaultValue.Valid {
+			definition += " DEFAULT " + strings.Join(strings.Fields(column.DefaultValue.String), " ")
+		}
+		definitions = append(definitions, definition)
 
-		if settings.VVerbose {
-			fmt.Printf("\t\t> %v\r\n", column.Name)
+		if db.IsPrimaryKey(column) {
+			primaryKeys = append(primaryKeys, column.Name)
 		}
+	}
+
+	if len(primaryKeys) > 0 {
+		definitions = append(definitions, "PRIMARY KEY ("+strings.Join(primaryKeys, ", ")+")")
+	}
 
-		columnType, col := mapDbColumnTypeToGoType(settings, db, column)
+	var ddl strings.Builder
 
-		// save that we saw types of columns at least once
-		if !columnInfo.isTemporal {
-			columnInfo.isTemporal = col.isTemporal
-		}
-		if !columnInfo.isNullable {
-			columnInfo.isNullable = col.isNullable
+	ddl.WriteString("// CREATE TABLE ")
+	ddl.WriteString(table.Name)
+	ddl.WriteString(" (\n")
+	for i, definition := range definitions {
+		ddl.WriteString("//   ")
+		ddl.WriteString(definition)
+		if i < len(definitions)-1 {
+			ddl.WriteString(",")
 		}
-
-		structFields.WriteString(columnName)
-		structFields.WriteString(" ")
-		structFields.WriteString(columnType)
-		structFields.WriteString(" ")
-		structFields.WriteString(taggers.GenerateTag(db, column))
-		structFields.WriteString("\n")
+		ddl.WriteString("\n")
 	}
+	ddl.WriteString("// )\n")
 
-	if settings.IsMastermindStructableRecorder {
-		structFields.WriteString("\t\nstructable.Recorder\n")
+	return ddl.String()
+}
+
+// keysComment creates the comment of the distribution and sort keys of
+// Redshift the column is part of, if any, eg. `distkey, sortkey 1`.
+func keysComment(column database.Column) string {
+	var keys []string
+	if column.DistKey {
+		keys = append(keys, "distkey")
 	}
+	switch {
+	case column.SortKeyOrder > 0:
+		keys = append(keys, "sortkey "+strconv.Itoa(column.SortKeyOrder))
+	case column.SortKeyOrder < 0:
+		keys = append(keys, "interleaved sortkey "+strconv.Itoa(-column.SortKeyOrder))
+	}
+	return strings.Join(keys, ", ")
+}
 
-	var fileContent strings.Builder
+// createGettersString creates a getter method for each field of the struct
+// and an interface grouping them, eg. for mocks of the struct. The getters
+// are named after the exported names of the fields, so unexported fields of
+// the struct of the given type name can be read from other packages.
+func createGettersString(tableName, typeName string, fieldNames, exportedFieldNames, fieldTypes []string) string {
 
-	// write header infos
-	fileContent.WriteString("package ")
-	fileContent.WriteString(settings.PackageName)
-	fileContent.WriteString("\n\n")
+	var getters strings.Builder
 
-	// write imports
-	generateImports(&fileContent, settings, columnInfo)
+	receiver := strings.ToLower(string([]rune(tableName)[0]))
 
-	// write struct with fields
-	fileContent.WriteString("type ")
-	fileContent.WriteString(tableName)
-	fileContent.WriteString(" struct {\n")
-	fileContent.WriteString(structFields.String())
-	fileContent.WriteString("}")
+	for i, fieldName := range fieldNames {
+		getters.WriteString("\n\nfunc (")
+		getters.WriteString(receiver)
+		getters.WriteString(" ")
+		getters.WriteString(typeName)
+		getters.WriteString(") Get")
+		getters.WriteString(exportedFieldNames[i])
+		getters.WriteString("() ")
+		getters.WriteString(fieldTypes[i])
+		getters.WriteString(" {\nreturn ")
+		getters.WriteString(receiver)
+		getters.WriteString(".")
+		getters.WriteString(fieldName)
+		getters.WriteString("\n}")
+	}
 
-	return tableName, fileContent.String(), nil
+	getters.WriteString("\n\ntype ")
+	getters.WriteString(tableName)
+	getters.WriteString("Getter interface {\n")
+	for i, fieldName := range exportedFieldNames {
+		getters.WriteString("Get")
+		getters.WriteString(fieldName)
+		getters.WriteString("() ")
+		getters.WriteString(fieldTypes[i])
+		getters.WriteString("\n")
+	}
+	getters.WriteString("}")
+
+	return getters.String()
 }
 
-func generateImports(content *strings.Builder, settings *settings.Settings, columnInfo columnInfo) {
+// createEnumTypeString creates a named string type with the given name and a
+// constant for each allowed value of the enum column.
+func createEnumTypeString(typeName string, column database.Column) string {
 
-	if !columnInfo.isNullableOrTemporal() && !settings.IsMastermindStructableRecorder {
-		return
+	var enumType strings.Builder
+
+	enumType.WriteString("\n\ntype ")
+	enumType.WriteString(typeName)
+	enumType.WriteString(" string")
+
+	values := column.EnumValues()
+	if len(values) == 0 {
+		return enumType.String()
 	}
 
-	content.WriteString("import (\n")
+	enumType.WriteString("\n\nconst (\n")
+
+	names := map[string]struct{}{}
+	for i, value := range values {
+		name := camelCaseString(strings.Map(replaceSpace, strings.ToLower(value)))
+		name = strings.Map(dropInvalidRune, name)
+		// values consisting of invalid characters only or colliding after
+		// dropping them are named after their position
+		if _, ok := names[name]; ok || name == "" {
+			name += strconv.Itoa(i + 1)
+		}
+		names[name] = struct{}{}
+
+		enumType.WriteString(typeName)
+		enumType.WriteString(name)
+		enumType.WriteString(" ")
+		enumType.WriteString(typeName)
+		enumType.WriteString(" = ")
+		enumType.WriteString(strconv.Quote(value))
+		enumType.WriteString("\n")
+	}
+
+	enumType.WriteString(")")
+
+	return enumType.String()
+}
+
+func generateImports(content *strings.Builder, settings *settings.Settings, columnInfo columnInfo) {
+
+	var imports []string
 
 	if columnInfo.isNullable && settings.IsNullTypeSQL() {
-		content.WriteString("\t\"database/sql\"\n")
+		imports = append(imports, "database/sql")
 	}
 
 	if columnInfo.isTemporal {
-		content.WriteString("\t\"time\"\n")
+		imports = append(imports, "time")
+	}
+
+	// eg. time of a time.Duration next to temporal columns
+	for _, imp := range columnInfo.imports {
+		if !isStringInSlice(imp, imports) {
+			imports = append(imports, imp)
+		}
 	}
 
 	if settings.IsMastermindStructableRecorder {
-		content.WriteString("\t\n\"github.com/Masterminds/structable\"\n")
+		imports = append(imports, "github.com/Masterminds/structable")
+	}
+
+	// the content stays valid even unformatted, without an empty import block
+	if len(imports) == 0 {
+		return
 	}
 
+	content.WriteString("import (\n")
+	for _, imp := range imports {
+		content.WriteString("\t\"")
+		content.WriteString(imp)
+		content.WriteString("\"\n")
+	}
 	content.WriteString(")\n\n")
 }
 
-func mapDbColumnTypeToGoType(s *settings.Settings, db database.Database, column database.Column) (goType string, columnInfo columnInfo) {
-	if db.IsInteger(column) {
+func mapDbColumnTypeToGoType(s *settings.Settings, db database.Database, tableName string, column database.Column) (goType string, columnInfo columnInfo) {
+	if typ, ok := s.OverriddenType(tableName, column.Name); ok {
+		// taken as is, regardless of the datatype and the nullability
+		goType, columnInfo.imports = qualifiedType(typ)
+	} else if typ, ok := s.DomainMap[column.DomainName.String]; ok && column.DomainName.Valid {
+		// the type of the domain rather than of its underlying base type
+		goType, columnInfo.imports = qualifiedType(typ)
+		if db.IsNullable(column) && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "*") {
+			goType = "*" + goType
+		}
+	} else if s.IDType != "" && column.IsPrimaryKey && db.IsInteger(column) {
+		// the column is known to be part of the primary key at this point,
+		// even if the row of another constraint of it was kept
+		goType, columnInfo.imports = qualifiedType(s.IDType)
+		if db.IsNullable(column) && !strings.HasPrefix(goType, "*") {
+			goType = "*" + goType
+		}
+	} else if s.TinyIntBool && isTinyIntBool(column) {
+		goType = "bool"
+		if db.IsNullable(column) {
+			goType = getNullType(s, "*bool", "sql.NullBool")
+			columnInfo.isNullable = true
+		}
+	} else if db.IsInteger(column) && db.IsUnsigned(column) {
+		goType = "uint"
+		if column.DataType == "bigint" {
+			goType = "uint64"
+		}
+		if db.IsNullable(column) {
+			goType = getNullType(s, "*"+goType, "sql.NullInt64")
+			columnInfo.isNullable = true
+			if goType == "sql.NullInt64" && column.DataType == "bigint" {
+				columnInfo.comment = "unsigned, values above math.MaxInt64 overflow"
+			}
+		}
+	} else if db.IsInteger(column) {
 		goType = "int"
 		if db.IsNullable(column) {
 			goType = getNullType(s, "*int", "sql.NullInt64")
 			columnInfo.isNullable = true
 		}
+	} else if s.Decimal && db.IsFloat(column) && isStringInSlice(column.DataType, decimalDatatypes) {
+		goType = "decimal.Decimal"
+		if db.IsNullable(column) {
+			goType = getNullType(s, "*decimal.Decimal", "decimal.NullDecimal")
+		}
+		columnInfo.imports = []string{"github.com/shopspring/decimal"}
 	} else if db.IsFloat(column) {
 		goType = "float64"
 		if db.IsNullable(column) {
@@ -221,18 +2119,117 @@ func mapDbColumnTypeToGoType(s *settings.Settings, db database.Database, column
 			columnInfo.isTemporal = true
 		} else {
 			goType = getNullType(s, "*time.Time", "sql.NullTime")
-			columnInfo.isTemporal = s.Null == settings.NullTypeNative
+			columnInfo.isTemporal = !s.IsNullTypeSQL()
 			columnInfo.isNullable = true
 		}
+	} else if db.IsBit(column) {
+		// single bits are flags, multiple bits are kept as raw bytes which
+		// represent NULL as nil
+		goType = "[]byte"
+		if column.BitLength() == 1 {
+			goType = "bool"
+			if db.IsNullable(column) {
+				goType = getNullType(s, "*bool", "sql.NullBool")
+				columnInfo.isNullable = true
+			}
+		}
+	} else if db.IsArray(column) {
+		// the arrays represent NULL as nil
+		goType = "[]byte"
+		if arrayType, ok := pqArrayTypes[column.UdtName]; ok {
+			goType = arrayType
+			columnInfo.imports = []string{"github.com/lib/pq"}
+		}
+	} else if netType, ok := netTypes[column.DataType]; ok && !s.NetStrings {
+		// the slices represent NULL as nil, only the struct needs a pointer
+		goType = netType
+		if goType == "NetIPNet" && db.IsNullable(column) {
+			goType = "*NetIPNet"
+		}
+	} else if isGeometry(column) {
+		// the geometries are given as well-known binary (WKB) by default
+		goType, columnInfo.imports = qualifiedType(s.GeomType)
+		if db.IsNullable(column) && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "*") {
+			goType = "*" + goType
+		}
+	} else if s.Hstore && isHstore(column) {
+		// the map of the hstore represents NULL as nil
+		goType = "hstore.Hstore"
+		columnInfo.imports = []string{"github.com/lib/pq/hstore"}
+	} else if s.JSONRaw && db.IsJSON(column) {
+		goType = "json.RawMessage"
+		if db.IsNullable(column) {
+			goType = "*json.RawMessage"
+		}
+		columnInfo.imports = []string{"encoding/json"}
+	} else if column.DataType == "interval" {
+		// the intervals are given in the format of the database, other types
+		// than string have to parse it, eg. by implementing sql.Scanner
+		if s.IntervalType == "string" {
+			goType = "string"
+			if db.IsNullable(column) {
+				goType = getNullType(s, "*string", "sql.NullString")
+				columnInfo.isNullable = true
+			}
+			columnInfo.comment = "interval"
+		} else {
+			goType, columnInfo.imports = qualifiedType(s.IntervalType)
+			if db.IsNullable(column) && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "*") {
+				goType = "*" + goType
+			}
+		}
 	} else {
 		// TODO handle special data types
 		switch column.DataType {
-		case "boolean":
+		case "boolean", "bool":
 			goType = "bool"
 			if db.IsNullable(column) {
 				goType = getNullType(s, "*bool", "sql.NullBool")
 				columnInfo.isNullable = true
 			}
+		case "tinytext", "text", "mediumtext", "longtext", "tinyblob", "blob", "mediumblob", "longblob":
+			// the raw bytes of blobs represent NULL as nil
+			if s.BlobBytes && strings.HasSuffix(column.DataType, "blob") {
+				goType = "[]byte"
+				break
+			}
+			goType = "string"
+			if db.IsNullable(column) {
+				goType = getNullType(s, "*string", "sql.NullString")
+				columnInfo.isNullable = true
+			}
+		case "varbyte", "binary varying":
+			// Redshift gives the bytes hex encoded, which represent NULL as nil
+			goType = "[]byte"
+			columnInfo.comment = "varbyte, hex encoded"
+		case "money":
+			// the output of money depends on the locale of the database, eg.
+			// `$1,000.00`, unlike the one of money cast to numeric
+			if s.Decimal {
+				goType = "decimal.Decimal"
+				if db.IsNullable(column) {
+					goType = getNullType(s, "*decimal.Decimal", "decimal.NullDecimal")
+				}
+				columnInfo.imports = []string{"github.com/shopspring/decimal"}
+				columnInfo.comment = "money, select it cast to numeric, its format depends on lc_monetary"
+				break
+			}
+			goType = "string"
+			if db.IsNullable(column) {
+				goType = getNullType(s, "*string", "sql.NullString")
+				columnInfo.isNullable = true
+			}
+			columnInfo.comment = "money, formatted according to lc_monetary"
+		case "uuid":
+			if s.UUID {
+				goType = "uuid.UUID"
+				if db.IsNullable(column) {
+					goType = "*uuid.UUID"
+				}
+				columnInfo.imports = []string{"github.com/google/uuid"}
+				break
+			}
+			fallthrough
 		default:
 			// Everything else we cannot detect defaults to (nullable) string.
 			goType = "string"
@@ -243,27 +2240,172 @@ func mapDbColumnTypeToGoType(s *settings.Settings, db database.Database, column
 		}
 	}
 
+	// the columns of unmapped domains keep the type of the base type, the
+	// domain is noted at least
+	_, isOverridden := s.OverriddenType(tableName, column.Name)
+	_, isDomainMapped := s.DomainMap[column.DomainName.String]
+	if column.DomainName.Valid && !isOverridden && !isDomainMapped {
+		if columnInfo.comment != "" {
+			columnInfo.comment += "; "
+		}
+		columnInfo.comment += "domain: " + column.DomainName.String
+	}
+
 	return goType, columnInfo
 }
 
+// isTinyIntBool checks if the column is a MySQL tinyint(1), which is commonly
+// used for booleans.
+func isTinyIntBool(column database.Column) bool {
+	return column.DataType == "tinyint" && strings.HasPrefix(column.ColumnType, "tinyint(1)")
+}
+
+// isGeometry checks if the column is of a spatial datatype. PostGIS types
+// are user defined types in Postgresql.
+func isGeometry(column database.Column) bool {
+	if column.DataType == "USER-DEFINED" {
+		return isStringInSlice(column.UdtName, geometryDatatypes)
+	}
+	return isStringInSlice(column.DataType, geometryDatatypes)
+}
+
+// isHstore checks if the column is of the hstore datatype of Postgresql, which
+// is a user defined type of the hstore extension.
+func isHstore(column database.Column) bool {
+	return column.DataType == "USER-DEFINED" && column.UdtName == "hstore" || column.DataType == "hstore"
+}
+
+// qualifiedType splits a type qualified by the path of its package, eg.
+// `github.com/paulmach/orb.Geometry`, into the type as used in go code,
+// `orb.Geometry`, and the imports needed for it.
+func qualifiedType(typ string) (goType string, imports []string) {
+	// modifiers of the type like slices or pointers are kept as they are
+	name := strings.TrimLeft(typ, "[]*")
+	modifiers := typ[:len(typ)-len(name)]
+
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return typ, nil
+	}
+
+	pkg := name[:i]
+
+	return modifiers + path.Base(pkg) + name[i:], []string{pkg}
+}
+
+// uniqueColumnName returns the given column name, or if it is already used the
+// column name with the first numeric suffix not used yet, eg. `UserID2`.
+func uniqueColumnName(used map[string]struct{}, columnName string) string {
+	if _, ok := used[columnName]; !ok {
+		return columnName
+	}
+	for i := 2; ; i++ {
+		name := columnName + strconv.Itoa(i)
+		if _, ok := used[name]; !ok {
+			return name
+		}
+	}
+}
+
 func camelCaseString(s string) string {
+	return camelCase(s, false, nil)
+}
+
+// camelCaseInitialisms works like camelCaseString but upper cases the words
+// which are common initialisms or one of the extra ones, eg. `api_key`
+// becomes `APIKey`.
+func camelCaseInitialisms(s string, extra []string) string {
+	return camelCase(s, true, extra)
+}
+
+func camelCase(s string, initialisms bool, extra []string) string {
 	if s == "" {
 		return s
 	}
 
-	splitted := strings.Split(s, "_")
-
-	if len(splitted) == 1 {
-		return caser.String(s)
-	}
+	splitted := strings.FieldsFunc(s, isWordSeparator)
 
 	var cc string
 	for _, part := range splitted {
-		cc += caser.String(strings.ToLower(part))
+		if initialisms && isInitialism(part, extra) {
+			cc += strings.ToUpper(part)
+			continue
+		}
+		if len(splitted) == 1 {
+			return titleCase(part)
+		}
+		// parts in a single case are words on their own, the words of mixed
+		// case parts keep their case, eg. `userID` stays `UserID`
+		if part == strings.ToLower(part) || part == strings.ToUpper(part) {
+			cc += titleCase(strings.ToLower(part))
+			continue
+		}
+		for _, word := range splitCamelCase(part) {
+			if initialisms && isInitialism(word, extra) {
+				cc += strings.ToUpper(word)
+				continue
+			}
+			cc += titleCase(word)
+		}
 	}
 	return cc
 }
 
+// isInitialism reports whether the word is a common initialism or one of the
+// extra ones, regardless of its case.
+func isInitialism(word string, extra []string) bool {
+	if commonInitialisms[strings.ToUpper(word)] {
+		return true
+	}
+	for _, initialism := range extra {
+		if strings.EqualFold(word, initialism) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordSeparator reports whether the rune separates the words of a name.
+func isWordSeparator(r rune) bool {
+	return r == '_' || r == '-' || r == ' '
+}
+
+// splitCamelCase splits a camel case string into its words. A run of upper
+// case letters is a word on its own, eg. `HTTPHost` becomes `HTTP`, `Host`.
+func splitCamelCase(s string) []string {
+	runes := []rune(s)
+
+	var (
+		words []string
+		start int
+	)
+
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		prev := runes[i-1]
+		nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextIsLower {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	return append(words, string(runes[start:]))
+}
+
+// titleCase converts the first letter of each word to upper case. A new caser
+// is used on each call since a caser must not be shared between the workers.
+// Strings not starting with a letter are kept as they are, otherwise the caser
+// upper cases the first letter following the digits, eg: `1st` becomes `1St`.
+func titleCase(s string) string {
+	if !startsWithLetter(s) {
+		return s
+	}
+	return cases.Title(language.English, cases.NoLower).String(s)
+}
+
 func getNullType(settings *settings.Settings, primitive string, sql string) string {
 	if settings.IsNullTypeSQL() {
 		return sql
@@ -283,6 +2425,16 @@ func toInitialisms(s string) string {
 	return s
 }
 
+// isStringInSlice checks if needle (string) is in haystack ([]string).
+func isStringInSlice(needle string, haystack []string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
 func indexCaseInsensitive(s, substr string) int {
 	s, substr = strings.ToLower(s), strings.ToLower(substr)
 	return strings.Index(s, substr)
@@ -299,6 +2451,30 @@ func validVariableName(s string) bool {
 	return true
 }
 
+// dropInvalidRune drops any characters outside of Unicode letters, numbers and
+// underscore to create valid Go identifiers.
+func dropInvalidRune(r rune) rune {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+		return r
+	}
+	return -1
+}
+
+// startsWithLetter checks if the first character of s is a Unicode letter.
+func startsWithLetter(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLetter(r)
+}
+
+// identifierPrefix returns the prefix for identifiers not starting with a
+// letter according to the provided settings.
+func identifierPrefix(settings *settings.Settings) string {
+	if settings.IsOutputFormatCamelCase() {
+		return "X"
+	}
+	return "X_"
+}
+
 // ReplaceSpace swaps any Unicode space characters for underscores
 // to create valid Go identifiers
 func replaceSpace(r rune) rune {
@@ -308,45 +2484,67 @@ func replaceSpace(r rune) rune {
 	return r
 }
 
-// FormatColumnName checks for invalid characters and transforms a column name
+// FormatColumnName strips invalid characters and transforms a column name
 // according to the provided settings.
-func formatColumnName(settings *settings.Settings, column, table string) (string, error) {
+func formatColumnName(settings *settings.Settings, log *logger.Logger, column, table string) (string, error) {
+
+	// An explicitly given name overrides the computed one
+	if name, ok := settings.RenamedColumn(table, column); ok {
+		return name, nil
+	}
 
 	// Replace any whitespace with underscores
 	columnName := strings.Map(replaceSpace, column)
-	columnName = caser.String(columnName)
+	columnName = titleCase(columnName)
 
 	if settings.IsOutputFormatCamelCase() {
-		columnName = camelCaseString(columnName)
+		if settings.ShouldCommonInitialisms() {
+			columnName = camelCaseInitialisms(columnName, settings.ExtraInitialisms)
+		} else {
+			columnName = camelCaseString(columnName)
+		}
 	}
 	if settings.ShouldInitialism() {
 		columnName = toInitialisms(columnName)
 	}
 
-	// Check that the column name doesn't contain any invalid characters for Go variables
-	if !validVariableName(columnName) {
-		return "", fmt.Errorf("column name %q in table %q contains invalid characters", column, table)
+	// Strip any invalid characters for Go variables, the db-tag keeps the
+	// original name of the column
+	columnName = strings.Map(dropInvalidRune, columnName)
+	if columnName == "" {
+		return "", fmt.Errorf("column name %q in table %q contains no valid characters", column, table)
 	}
 
 	// First character of an identifier in Go must be letter or _
 	// We want it to be an uppercase letter to be a public field
-	if !unicode.IsLetter(rune(columnName[0])) {
-		prefix := "X_"
-		if settings.IsOutputFormatCamelCase() {
-			prefix = "X"
-		}
-		if settings.ShouldInitialism() {
-			// Note we use the original passed in name of the column here to
-			// avoid the Title'izing of the first non-digit character as done
-			// by cases.Caser. Eg: `1fish2fish` gets transformed to `X1Fish2fish`
-			// but we want `X1fish2fish`.
-			columnName = toInitialisms(column)
-		}
-		if settings.Verbose {
-			fmt.Printf("\t\t>column %q in table %q doesn't start with a letter; prepending with %q\n", column, table, prefix)
-		}
+	if !startsWithLetter(columnName) {
+		prefix := identifierPrefix(settings)
+		log.Debugf("\t\t>column %q in table %q doesn't start with a letter; prepending with %q\n", column, table, prefix)
 		columnName = prefix + columnName
 	}
 
-	return columnName, nil
+	return sanitizeKeyword(columnName), nil
+}
+
+// unexportedName lower cases the leading upper case letters of the name, but
+// the last one if it starts the next word, eg. `UserID` becomes `userID` and
+// `HTTPHost` becomes `httpHost`. Reserved keywords get sanitized.
+func unexportedName(name string) string {
+	runes := []rune(name)
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return sanitizeKeyword(string(runes))
+}
+
+// sanitizeKeyword appends an underscore to names which are reserved keywords
+// in Go, eg. `type` becomes `type_`. Exported names never collide with them.
+func sanitizeKeyword(name string) string {
+	if token.IsKeyword(name) {
+		return name + "_"
+	}
+	return name
 }
@@ -1,20 +1,38 @@
 package cli
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/logger"
+	"github.com/fraenky8/tables-to-go/pkg/output"
 	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/fraenky8/tables-to-go/pkg/tagger"
 )
 
+// quietLog keeps the progress of the functions under test out of the output
+// of the tests.
+var quietLog = logger.New(true, false, false)
+
 type mockDb struct {
 	mock.Mock
 	database.Database
 
 	tables []*database.Table
+
+	// columnsErr is returned by GetColumnsOfTable.
+	columnsErr error
 }
 
 func newMockDb(db database.Database) *mockDb {
@@ -31,17 +49,32 @@ func (db *mockDb) Close() (err error) {
 	return nil
 }
 
-func (db *mockDb) GetTables() (tables []*database.Table, err error) {
+func (db *mockDb) GetTables(_ context.Context) (tables []*database.Table, err error) {
 	db.Called()
 	return db.tables, nil
 }
 
-func (db *mockDb) PrepareGetColumnsOfTableStmt() (err error) {
+func (db *mockDb) GetRoutines(_ context.Context) (routines []*database.Table, err error) {
+	args := db.Called()
+	return args.Get(0).([]*database.Table), nil
+}
+
+func (db *mockDb) PrepareGetColumnsOfTableStmt(_ context.Context) (err error) {
 	db.Called()
 	return nil
 }
 
-func (db *mockDb) GetColumnsOfTable(table *database.Table) (err error) {
+func (db *mockDb) GetColumnsOfTable(_ context.Context, table *database.Table) (err error) {
+	db.Called(table)
+	return db.columnsErr
+}
+
+func (db *mockDb) GetColumnsOfTables(_ context.Context, tables []*database.Table) (err error) {
+	db.Called(tables)
+	return nil
+}
+
+func (db *mockDb) GetForeignKeysOfTable(_ context.Context, table *database.Table) (err error) {
 	db.Called(table)
 	return nil
 }
@@ -59,6 +92,256 @@ func (w *mockWriter) Write(tableName string, content string) error {
 	return nil
 }
 
+// assertRunWritesTable runs the transformations for the single given table
+// and asserts the expected file name and content get written.
+func assertRunWritesTable(t *testing.T, s *settings.Settings, table *database.Table, fileName, content string) {
+	t.Helper()
+
+	mdb := newMockDb(database.New(s))
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			fileName,
+			content,
+		)
+
+	err := Run(s, mdb, w)
+	assert.NoError(t, err)
+}
+
+// ctxDb records the context passed to GetTables and fails if it is done.
+type ctxDb struct {
+	*mockDb
+	ctx context.Context
+}
+
+func (db *ctxDb) GetTables(ctx context.Context) (tables []*database.Table, err error) {
+	db.ctx = ctx
+	return nil, ctx.Err()
+}
+
+func TestRunContext(t *testing.T) {
+	t.Run("cancelled context stops the run", func(t *testing.T) {
+		s := settings.New()
+		db := &ctxDb{mockDb: newMockDb(database.New(s))}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := RunContext(ctx, s, db, newMockWriter())
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("timeout sets the deadline of the context", func(t *testing.T) {
+		s := settings.New()
+		s.Timeout = time.Minute
+		db := &ctxDb{mockDb: newMockDb(database.New(s))}
+		db.On("PrepareGetColumnsOfTableStmt")
+
+		err := RunContext(context.Background(), s, db, newMockWriter())
+		assert.NoError(t, err)
+
+		_, ok := db.ctx.Deadline()
+		assert.True(t, ok)
+	})
+}
+
+func TestRun_NoTables(t *testing.T) {
+	tests := []struct {
+		desc          string
+		settings      func() *settings.Settings
+		tables        []*database.Table
+		expectedError string
+	}{
+		{
+			desc: "no tables found only warns by default",
+			settings: func() *settings.Settings {
+				return settings.New()
+			},
+		},
+		{
+			desc: "no tables found fails if enabled",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.FailOnEmpty = true
+				return s
+			},
+			expectedError: `no tables found in schema "public"`,
+		},
+		{
+			desc: "no tables found in multiple schemas fails if enabled",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.FailOnEmpty = true
+				s.Schema = "public,audit"
+				return s
+			},
+			expectedError: `no tables found in schemas ["public" "audit"]`,
+		},
+		{
+			desc: "all tables filtered fails if enabled",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.FailOnEmpty = true
+				s.Include = settings.List{"posts"}
+				return s
+			},
+			tables:        []*database.Table{{Name: "users"}},
+			expectedError: "no tables found matching -include/-exclude, all 1 tables skipped",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := test.settings()
+			db := newMockDb(database.New(s))
+			db.tables = test.tables
+			db.
+				On("GetTables").
+				Return(db.tables, nil)
+			db.
+				On("PrepareGetColumnsOfTableStmt").
+				Return(nil)
+
+			err := Run(s, db, newMockWriter())
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrNoTables)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestRun_BatchColumns(t *testing.T) {
+	s := settings.New()
+	s.BatchColumns = true
+	s.Routines = true
+
+	table := &database.Table{Name: "users"}
+	routine := &database.Table{
+		Name:    "active_users",
+		Routine: true,
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+		},
+	}
+
+	db := newMockDb(database.New(s))
+	db.tables = []*database.Table{table}
+	db.
+		On("GetTables").
+		Return(db.tables, nil)
+	db.
+		On("GetRoutines").
+		Return([]*database.Table{routine}, nil)
+	db.
+		On("GetColumnsOfTables", []*database.Table{table}).
+		Run(func(args mock.Arguments) {
+			table.Columns = []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+			}
+		})
+
+	w := newMockWriter()
+	w.
+		On("Write", "Users", "package dto\n\ntype Users struct {\nID int `db:\"id\"`\n}")
+	w.
+		On("Write", "ActiveUsersResult", "package dto\n\ntype ActiveUsersResult struct {\nID int `db:\"id\"`\n}")
+
+	err := Run(s, db, w)
+	assert.NoError(t, err)
+
+	db.AssertExpectations(t)
+	db.AssertNotCalled(t, "PrepareGetColumnsOfTableStmt")
+	db.AssertNotCalled(t, "GetColumnsOfTable", table)
+	w.AssertExpectations(t)
+}
+
+func TestRun_ColumnsError(t *testing.T) {
+	s := settings.New()
+
+	table := &database.Table{Name: "test_table"}
+
+	mdb := newMockDb(database.New(s))
+	mdb.tables = append(mdb.tables, table)
+	mdb.columnsErr = errors.New("relation does not exist")
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+
+	err := Run(s, mdb, w)
+	assert.ErrorIs(t, err, mdb.columnsErr)
+	w.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+}
+
+func TestProcessTables_Stats(t *testing.T) {
+	s := settings.New()
+	s.Force = true
+
+	tables := []*database.Table{
+		{
+			Name: "users",
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer", ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true}},
+				{OrdinalPosition: 1, Name: "id", DataType: "integer", ConstraintType: sql.NullString{String: "UNIQUE", Valid: true}},
+				{OrdinalPosition: 2, Name: "name", DataType: "text"},
+			},
+		},
+		{
+			Name: "posts",
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer"},
+			},
+		},
+		{
+			Name: "!!!",
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer"},
+			},
+		},
+	}
+
+	mdb := newMockDb(database.New(s))
+	mdb.On("GetColumnsOfTable", mock.Anything)
+
+	w := newMockWriter()
+	w.On("Write", mock.Anything, mock.Anything)
+
+	st := &stats{}
+	err := processTables(context.Background(), s, quietLog, tagger.NewTaggers(s), mdb, w, nil, nil, tables, st)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &stats{
+		tables:    3,
+		structs:   2,
+		columns:   3,
+		skipped:   1,
+		generated: map[*database.Table]bool{tables[0]: true, tables[1]: true},
+	}, st)
+	assert.Equal(t, "Generated 2 structs (3 columns) in ./output, skipped 1 of 3 tables", st.summary("./output"))
+}
+
 func TestCamelCaseString(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -89,6 +372,141 @@ func TestCamelCaseString(t *testing.T) {
 	}
 }
 
+func TestCamelCaseSeparatorsAndAcronyms(t *testing.T) {
+	tests := []struct {
+		input               string
+		expected            string
+		expectedInitialisms string
+	}{
+		{input: "user_id", expected: "UserId", expectedInitialisms: "UserID"},
+		{input: "user-id", expected: "UserId", expectedInitialisms: "UserID"},
+		{input: "user id", expected: "UserId", expectedInitialisms: "UserID"},
+		{input: "userID", expected: "UserID", expectedInitialisms: "UserID"},
+		{input: "HTTPHost", expected: "HTTPHost", expectedInitialisms: "HTTPHost"},
+		{input: "api_key", expected: "ApiKey", expectedInitialisms: "APIKey"},
+		{input: "oauth2_token", expected: "Oauth2Token", expectedInitialisms: "Oauth2Token"},
+		{input: "created_byUserID", expected: "CreatedByUserID", expectedInitialisms: "CreatedByUserID"},
+		{input: "the_HTTPHost-url", expected: "TheHTTPHostUrl", expectedInitialisms: "TheHTTPHostURL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, camelCaseString(tt.input))
+			assert.Equal(t, tt.expectedInitialisms, camelCaseInitialisms(tt.input, nil))
+		})
+	}
+}
+
+func TestCamelCaseInitialisms(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected string
+	}{
+		{
+			desc:     "single initialism gets upper cased",
+			input:    "id",
+			expected: "ID",
+		},
+		{
+			desc:     "initialism words get upper cased",
+			input:    "api_key_of_user_uuid",
+			expected: "APIKeyOfUserUUID",
+		},
+		{
+			desc:     "initialisms within words are kept",
+			input:    "identity_ipsum",
+			expected: "IdentityIpsum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual := camelCaseInitialisms(tt.input, nil)
+			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
+		})
+	}
+}
+
+func TestFormatColumnName_Initialisms(t *testing.T) {
+	tests := []struct {
+		desc      string
+		input     string
+		expected  string
+		expectedI string // with -initialisms
+		expectedN string // with -no-initialism
+		extra     settings.List
+	}{
+		{
+			desc:      "id",
+			input:     "user_id",
+			expected:  "UserID",
+			expectedI: "UserID",
+			expectedN: "UserId",
+		},
+		{
+			desc:      "common initialisms",
+			input:     "api_url",
+			expected:  "ApiURL",
+			expectedI: "APIURL",
+			expectedN: "ApiUrl",
+		},
+		{
+			desc:      "common initialisms are kept by default",
+			input:     "ip_address",
+			expected:  "IpAddress",
+			expectedI: "IPAddress",
+			expectedN: "IpAddress",
+		},
+		{
+			desc:      "extra initialisms",
+			input:     "user_ssn_ein",
+			expected:  "UserSsnEin",
+			expectedI: "UserSSNEIN",
+			expectedN: "UserSsnEin",
+			extra:     settings.List{"ssn", "EIN"},
+		},
+		{
+			desc:      "extra initialisms within words are kept",
+			input:     "lessons",
+			expected:  "Lessons",
+			expectedI: "Lessons",
+			expectedN: "Lessons",
+			extra:     settings.List{"ssn"},
+		},
+		{
+			desc:      "extra initialisms match regardless of their case",
+			input:     "USER_SSN",
+			expected:  "UserSsn",
+			expectedI: "UserSSN",
+			expectedN: "UserSsn",
+			extra:     settings.List{"ssn"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := settings.New()
+
+			actual, err := formatColumnName(s, quietLog, tt.input, "users")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+
+			s.Initialisms = true
+			s.ExtraInitialisms = tt.extra
+
+			actual, err = formatColumnName(s, quietLog, tt.input, "users")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedI, actual)
+
+			s.Initialisms = false
+			s.ExtraInitialisms = nil
+			s.NoInitialism = true
+
+			actual, err = formatColumnName(s, quietLog, tt.input, "users")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedN, actual)
+		})
+	}
+}
+
 func TestToInitialisms(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -270,7 +688,7 @@ func TestRun_StringTextColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -361,7 +779,7 @@ func TestRun_StringTextColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -566,7 +984,7 @@ func TestRun_IntegerColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *int `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName *int `db:\"column_name\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -657,7 +1075,7 @@ func TestRun_IntegerColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *int `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *int `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -736,6 +1154,66 @@ func TestRun_IntegerColumns(t *testing.T) {
 	}
 }
 
+func TestRun_UnsignedIntegerColumns(t *testing.T) {
+	newTable := func() *database.Table {
+		return &database.Table{
+			Name: "test_table",
+			Columns: []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "id",
+					DataType:        "bigint",
+					ColumnType:      "bigint unsigned",
+					IsNullable:      "NO",
+				},
+				{
+					OrdinalPosition: 2,
+					Name:            "counter",
+					DataType:        "int",
+					ColumnType:      "int(10) unsigned",
+					IsNullable:      "NO",
+				},
+				{
+					OrdinalPosition: 3,
+					Name:            "total",
+					DataType:        "bigint",
+					ColumnType:      "bigint(20) unsigned",
+					IsNullable:      "YES",
+				},
+				{
+					OrdinalPosition: 4,
+					Name:            "amount",
+					DataType:        "smallint",
+					ColumnType:      "smallint unsigned",
+					IsNullable:      "YES",
+				},
+				{
+					OrdinalPosition: 5,
+					Name:            "delta",
+					DataType:        "int",
+					ColumnType:      "int",
+					IsNullable:      "NO",
+				},
+			},
+		}
+	}
+
+	t.Run("sql null type", func(t *testing.T) {
+		s := settings.New()
+		s.DbType = settings.DBTypeMySQL
+		assertRunWritesTable(t, s, newTable(), "TestTable",
+			"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nID uint64 `db:\"id\"`\nCounter uint `db:\"counter\"`\nTotal sql.NullInt64 `db:\"total\"` // unsigned, values above math.MaxInt64 overflow\nAmount sql.NullInt64 `db:\"amount\"`\nDelta int `db:\"delta\"`\n}")
+	})
+
+	t.Run("primitive null type", func(t *testing.T) {
+		s := settings.New()
+		s.DbType = settings.DBTypeMySQL
+		s.Null = settings.NullTypePrimitive
+		assertRunWritesTable(t, s, newTable(), "TestTable",
+			"package dto\n\ntype TestTable struct {\nID uint64 `db:\"id\"`\nCounter uint `db:\"counter\"`\nTotal *uint64 `db:\"total\"`\nAmount *uint `db:\"amount\"`\nDelta int `db:\"delta\"`\n}")
+	})
+}
+
 func TestRun_FloatColumns(t *testing.T) {
 	for dbType := range settings.SupportedDbTypes {
 		t.Run(dbType.String(), func(t *testing.T) {
@@ -862,7 +1340,7 @@ func TestRun_FloatColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *float64 `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName *float64 `db:\"column_name\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -953,7 +1431,7 @@ func TestRun_FloatColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *float64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *float64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -1032,6 +1510,176 @@ func TestRun_FloatColumns(t *testing.T) {
 	}
 }
 
+func TestRun_DecimalColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc:     "default maps decimal columns to float64",
+			settings: settings.New,
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nPrice float64 `db:\"price\"`\nDiscount sql.NullFloat64 `db:\"discount\"`\nRate float64 `db:\"rate\"`\n}",
+		},
+		{
+			desc: "enabled decimal maps decimal columns to decimal.Decimal",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Decimal = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"github.com/shopspring/decimal\"\n)\n\ntype TestTable struct {\nPrice decimal.Decimal `db:\"price\"`\nDiscount decimal.NullDecimal `db:\"discount\"`\nRate float64 `db:\"rate\"`\n}",
+		},
+		{
+			desc: "enabled decimal with native null type maps to pointers",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Decimal = true
+				s.Null = settings.NullTypeNative
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"github.com/shopspring/decimal\"\n)\n\ntype TestTable struct {\nPrice decimal.Decimal `db:\"price\"`\nDiscount *decimal.Decimal `db:\"discount\"`\nRate float64 `db:\"rate\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "price",
+						DataType:        "numeric",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "discount",
+						DataType:        "decimal",
+						IsNullable:      "YES",
+					},
+					{
+						OrdinalPosition: 3,
+						Name:            "rate",
+						DataType:        "real",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
+
+func TestRun_MoneyColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc:     "default maps money columns to string",
+			settings: settings.New,
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\n" +
+				"Price string `db:\"price\"` // money, formatted according to lc_monetary\n" +
+				"Discount sql.NullString `db:\"discount\"` // money, formatted according to lc_monetary\n}",
+		},
+		{
+			desc: "enabled decimal maps money columns to decimal.Decimal",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Decimal = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"github.com/shopspring/decimal\"\n)\n\ntype TestTable struct {\n" +
+				"Price decimal.Decimal `db:\"price\"` // money, select it cast to numeric, its format depends on lc_monetary\n" +
+				"Discount decimal.NullDecimal `db:\"discount\"` // money, select it cast to numeric, its format depends on lc_monetary\n}",
+		},
+		{
+			desc: "enabled decimal with native null type maps to pointers",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Decimal = true
+				s.Null = settings.NullTypeNative
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"github.com/shopspring/decimal\"\n)\n\ntype TestTable struct {\n" +
+				"Price decimal.Decimal `db:\"price\"` // money, select it cast to numeric, its format depends on lc_monetary\n" +
+				"Discount *decimal.Decimal `db:\"discount\"` // money, select it cast to numeric, its format depends on lc_monetary\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "price",
+						DataType:        "money",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "discount",
+						DataType:        "money",
+						IsNullable:      "YES",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
+
+func TestRun_HstoreColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc:     "default maps hstore columns to string",
+			settings: settings.New,
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\n" +
+				"Attributes string `db:\"attributes\"`\nTags sql.NullString `db:\"tags\"`\n}",
+		},
+		{
+			desc: "enabled hstore maps hstore columns to hstore.Hstore",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Hstore = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"github.com/lib/pq/hstore\"\n)\n\ntype TestTable struct {\n" +
+				"Attributes hstore.Hstore `db:\"attributes\"`\nTags hstore.Hstore `db:\"tags\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "attributes",
+						DataType:        "USER-DEFINED",
+						UdtName:         "hstore",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "tags",
+						DataType:        "USER-DEFINED",
+						UdtName:         "hstore",
+						IsNullable:      "YES",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
+
 func TestRun_TemporalColumns(t *testing.T) {
 	for dbType := range settings.SupportedDbTypes {
 		t.Run(dbType.String(), func(t *testing.T) {
@@ -1328,40 +1976,303 @@ func TestRun_TemporalColumns(t *testing.T) {
 	}
 }
 
-func TestRun_BooleanColumns(t *testing.T) {
-	for dbType := range settings.SupportedDbTypes {
-		t.Run(dbType.String(), func(t *testing.T) {
+func TestRun_TemporalColumnsImports(t *testing.T) {
+	createdAt := database.Column{
+		OrdinalPosition: 1,
+		Name:            "created_at",
+		DataType:        "timestamp",
+	}
+	deletedAt := database.Column{
+		OrdinalPosition: 2,
+		Name:            "deleted_at",
+		DataType:        "timestamp",
+		IsNullable:      "YES",
+	}
 
+	tests := []struct {
+		desc     string
+		nullType settings.NullType
+		columns  []database.Column
+		expected string
+	}{
+		{
+			desc:     "sql NULL type imports database/sql and time",
+			nullType: settings.NullTypeSQL,
+			columns:  []database.Column{createdAt, deletedAt},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable struct {\nCreatedAt time.Time `db:\"created_at\"`\nDeletedAt sql.NullTime `db:\"deleted_at\"`\n}",
+		},
+		{
+			desc:     "native NULL type imports only time",
+			nullType: settings.NullTypeNative,
+			columns:  []database.Column{createdAt, deletedAt},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nCreatedAt time.Time `db:\"created_at\"`\nDeletedAt *time.Time `db:\"deleted_at\"`\n}",
+		},
+		{
+			desc:     "primitive NULL type imports only time",
+			nullType: settings.NullTypePrimitive,
+			columns:  []database.Column{createdAt, deletedAt},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nCreatedAt time.Time `db:\"created_at\"`\nDeletedAt *time.Time `db:\"deleted_at\"`\n}",
+		},
+		{
+			desc:     "pointers NULL type imports only time",
+			nullType: settings.NullTypePointers,
+			columns:  []database.Column{createdAt, deletedAt},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nCreatedAt time.Time `db:\"created_at\"`\nDeletedAt *time.Time `db:\"deleted_at\"`\n}",
+		},
+		{
+			desc:     "primitive NULL type with only a nullable column imports time",
+			nullType: settings.NullTypePrimitive,
+			columns:  []database.Column{deletedAt},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nDeletedAt *time.Time `db:\"deleted_at\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
 			s := settings.New()
-			s.DbType = dbType
-			db := database.New(s)
+			s.Null = test.nullType
 
-			columnTypes := []string{"boolean"}
+			table := &database.Table{
+				Name:    "test_table",
+				Columns: test.columns,
+			}
 
-			for _, columnType := range columnTypes {
-				t.Run(columnType, func(t *testing.T) {
+			assertRunWritesTable(t, s, table, "TestTable", test.expected)
+		})
+	}
+}
 
-					t.Run("single table with NOT NULL column", func(t *testing.T) {
-						s := settings.New()
-						s.DbType = dbType
+func TestRun_UUIDColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc:     "default maps uuid columns to string",
+			settings: settings.New,
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nID string `db:\"id\"`\nParentID sql.NullString `db:\"parent_id\"`\n}",
+		},
+		{
+			desc: "enabled uuid maps uuid columns to uuid.UUID",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.UUID = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"github.com/google/uuid\"\n)\n\ntype TestTable struct {\nID uuid.UUID `db:\"id\"`\nParentID *uuid.UUID `db:\"parent_id\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "uuid",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "parent_id",
+						DataType:        "uuid",
+						IsNullable:      "YES",
+					},
+				},
+			}
 
-						mdb := newMockDb(db)
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
 
-						table := &database.Table{
-							Name: "test_table",
-							Columns: []database.Column{
-								{
-									OrdinalPosition: 1,
-									Name:            "column_name",
-									DataType:        columnType,
-								},
-							},
-						}
-						mdb.tables = append(mdb.tables, table)
+func TestRun_NetworkColumns(t *testing.T) {
+	tests := []struct {
+		desc       string
+		settings   func() *settings.Settings
+		expected   string
+		isNetTypes bool
+	}{
+		{
+			desc:       "default maps network columns to the types wrapping the ones of package net",
+			settings:   settings.New,
+			expected:   "package dto\n\ntype TestTable struct {\nAddress NetIP `db:\"address\"`\nNetwork *NetIPNet `db:\"network\"`\nMac NetHardwareAddr `db:\"mac\"`\n}",
+			isNetTypes: true,
+		},
+		{
+			desc: "enabled net-strings maps network columns to string",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.NetStrings = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nAddress string `db:\"address\"`\nNetwork sql.NullString `db:\"network\"`\nMac string `db:\"mac\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "address",
+						DataType:        "inet",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "network",
+						DataType:        "cidr",
+						IsNullable:      "YES",
+					},
+					{
+						OrdinalPosition: 3,
+						Name:            "mac",
+						DataType:        "macaddr",
+					},
+				},
+			}
 
-						mdb.
-							On("GetTables").
-							Return(mdb.tables, nil)
+			s := test.settings()
+
+			mdb := newMockDb(database.New(s))
+			mdb.tables = append(mdb.tables, table)
+			mdb.
+				On("GetTables").
+				Return(mdb.tables, nil)
+			mdb.
+				On("PrepareGetColumnsOfTableStmt").
+				Return(nil)
+			mdb.
+				On("GetColumnsOfTable", table)
+
+			w := newMockWriter()
+			w.On("Write", "TestTable", test.expected)
+			if test.isNetTypes {
+				w.On("Write", "NetTypes", "package dto\n\n"+netTypesSource)
+			}
+
+			err := Run(s, mdb, w)
+			assert.NoError(t, err)
+			w.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_NetworkColumnsScan(t *testing.T) {
+	s := settings.New()
+	s.PackageName = "main"
+
+	table := &database.Table{
+		Name: "hosts",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "address",
+				DataType:        "inet",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "network",
+				DataType:        "cidr",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "mac",
+				DataType:        "macaddr",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	_, content, err := createTableStructString(s, quietLog, tagger.NewTaggers(s), database.New(s), table, nil, false)
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	actual := runGenerated(t, map[string]string{
+		"hosts.go":     content,
+		"net_types.go": createNetTypesString(s),
+		"main.go": `package main
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net"
+)
+
+func main() {
+	db := openScanDB([]string{"address", "network", "mac"},
+		[]driver.Value{"10.0.0.1", "10.0.0.0/8", "08:00:2b:01:02:03"},
+		[]driver.Value{[]byte("192.168.1.5/24"), []byte("2001:db8::/32"), []byte("08:00:2b:01:02:04")},
+		[]driver.Value{nil, nil, nil},
+	)
+	rows, err := db.Query("SELECT address, network, mac FROM hosts")
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h Hosts
+		if err := rows.Scan(&h.Address, &h.Network, &h.Mac); err != nil {
+			panic(err)
+		}
+		network := "<nil>"
+		if h.Network != nil {
+			ipNet := net.IPNet(*h.Network)
+			network = ipNet.String()
+		}
+		fmt.Println(h.Address == nil, net.IP(h.Address), network, h.Mac == nil, net.HardwareAddr(h.Mac))
+	}
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+}
+`,
+	})
+
+	assert.Equal(t, "false 10.0.0.1 10.0.0.0/8 false 08:00:2b:01:02:03\n"+
+		"false 192.168.1.5 2001:db8::/32 false 08:00:2b:01:02:04\n"+
+		"true <nil> <nil> true \n", actual)
+}
+
+func TestRun_BooleanColumns(t *testing.T) {
+	for dbType := range settings.SupportedDbTypes {
+		t.Run(dbType.String(), func(t *testing.T) {
+
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+
+			columnTypes := []string{"boolean"}
+
+			for _, columnType := range columnTypes {
+				t.Run(columnType, func(t *testing.T) {
+
+					t.Run("single table with NOT NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
 						mdb.
 							On("PrepareGetColumnsOfTableStmt").
 							Return(nil)
@@ -1454,7 +2365,7 @@ func TestRun_BooleanColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *bool `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName *bool `db:\"column_name\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -1545,7 +2456,7 @@ func TestRun_BooleanColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *bool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *bool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -1624,6 +2535,519 @@ func TestRun_BooleanColumns(t *testing.T) {
 	}
 }
 
+func TestRun_JSONColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc:     "default maps json columns to string",
+			settings: settings.New,
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nData string `db:\"data\"`\nMeta sql.NullString `db:\"meta\"`\n}",
+		},
+		{
+			desc: "enabled json-raw maps json columns to json.RawMessage",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.JSONRaw = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"encoding/json\"\n)\n\ntype TestTable struct {\nData json.RawMessage `db:\"data\"`\nMeta *json.RawMessage `db:\"meta\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "data",
+						DataType:        "json",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "meta",
+						DataType:        "jsonb",
+						IsNullable:      "YES",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
+
+func TestRun_TinyIntBoolColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc: "default maps tinyint(1) columns to int",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nActive int `db:\"active\"`\nDeleted sql.NullInt64 `db:\"deleted\"`\nLevel int `db:\"level\"`\n}",
+		},
+		{
+			desc: "enabled tinyint-bool maps tinyint(1) columns to bool",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.TinyIntBool = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nActive bool `db:\"active\"`\nDeleted sql.NullBool `db:\"deleted\"`\nLevel int `db:\"level\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "active",
+						DataType:        "tinyint",
+						ColumnType:      "tinyint(1)",
+						IsNullable:      "NO",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "deleted",
+						DataType:        "tinyint",
+						ColumnType:      "tinyint(1)",
+						IsNullable:      "YES",
+					},
+					{
+						OrdinalPosition: 3,
+						Name:            "level",
+						DataType:        "tinyint",
+						ColumnType:      "tinyint(4)",
+						IsNullable:      "NO",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
+
+func TestRun_CockroachColumns(t *testing.T) {
+	s := settings.New()
+	s.DbType = settings.DBTypeCockroach
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "INT8",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "age",
+				DataType:        "int4",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "name",
+				DataType:        "STRING",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 4,
+				Name:            "score",
+				DataType:        "float8",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 5,
+				Name:            "active",
+				DataType:        "bool",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 6,
+				Name:            "created_at",
+				DataType:        "timestamptz",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	expected := "package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable struct {\nID int `db:\"id\"`\nAge sql.NullInt64 `db:\"age\"`\nName sql.NullString `db:\"name\"`\nScore float64 `db:\"score\"`\nActive bool `db:\"active\"`\nCreatedAt time.Time `db:\"created_at\"`\n}"
+
+	assertRunWritesTable(t, s, table, "TestTable", expected)
+}
+
+func TestRun_RedshiftColumns(t *testing.T) {
+	s := settings.New()
+	s.DbType = settings.DBTypeRedshift
+	s.JSONRaw = true
+
+	table := &database.Table{
+		Name: "events",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "bigint",
+				IsNullable:      "NO",
+				DistKey:         true,
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "created_at",
+				DataType:        "timestamp without time zone",
+				IsNullable:      "NO",
+				SortKeyOrder:    1,
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "kind",
+				DataType:        "character varying",
+				IsNullable:      "NO",
+				SortKeyOrder:    -2,
+			},
+			{
+				OrdinalPosition: 4,
+				Name:            "payload",
+				DataType:        "super",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 5,
+				Name:            "raw",
+				DataType:        "varbyte",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	expected := "package dto\n\nimport (\n\t\"time\"\n\t\"encoding/json\"\n)\n\ntype Events struct {\n" +
+		"ID int `db:\"id\"` // distkey\n" +
+		"CreatedAt time.Time `db:\"created_at\"` // sortkey 1\n" +
+		"Kind string `db:\"kind\"` // interleaved sortkey 2\n" +
+		"Payload *json.RawMessage `db:\"payload\"`\n" +
+		"Raw []byte `db:\"raw\"` // varbyte, hex encoded\n}"
+
+	assertRunWritesTable(t, s, table, "Events", expected)
+}
+
+func TestRun_MySQLJSONColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc: "default maps json columns to string",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nData string `db:\"data\"`\nMeta sql.NullString `db:\"meta\"`\n}",
+		},
+		{
+			desc: "enabled json-raw maps json columns to json.RawMessage",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.JSONRaw = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"encoding/json\"\n)\n\ntype TestTable struct {\nData json.RawMessage `db:\"data\"`\nMeta *json.RawMessage `db:\"meta\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "data",
+						DataType:        "json",
+						IsNullable:      "NO",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "meta",
+						DataType:        "json",
+						IsNullable:      "YES",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
+
+func TestRun_GeometryColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc:     "default maps geometry columns to bytes",
+			settings: settings.New,
+			expected: "package dto\n\ntype TestTable struct {\nLocation []byte `db:\"location\"`\nArea []byte `db:\"area\"`\nPosition []byte `db:\"position\"`\n}",
+		},
+		{
+			desc: "geom-type maps geometry columns to qualified type",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.GeomType = "github.com/paulmach/orb.Geometry"
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"github.com/paulmach/orb\"\n)\n\ntype TestTable struct {\nLocation orb.Geometry `db:\"location\"`\nArea *orb.Geometry `db:\"area\"`\nPosition orb.Geometry `db:\"position\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "location",
+						DataType:        "USER-DEFINED",
+						UdtName:         "geometry",
+						IsNullable:      "NO",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "area",
+						DataType:        "USER-DEFINED",
+						UdtName:         "geography",
+						IsNullable:      "YES",
+					},
+					{
+						OrdinalPosition: 3,
+						Name:            "position",
+						DataType:        "point",
+						IsNullable:      "NO",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
+
+func TestQualifiedType(t *testing.T) {
+	tests := []struct {
+		typ             string
+		expectedType    string
+		expectedImports []string
+	}{
+		{typ: "[]byte", expectedType: "[]byte"},
+		{typ: "string", expectedType: "string"},
+		{typ: "github.com/paulmach/orb.Geometry", expectedType: "orb.Geometry", expectedImports: []string{"github.com/paulmach/orb"}},
+		{typ: "*encoding/json.RawMessage", expectedType: "*json.RawMessage", expectedImports: []string{"encoding/json"}},
+	}
+	for _, test := range tests {
+		t.Run(test.typ, func(t *testing.T) {
+			actualType, actualImports := qualifiedType(test.typ)
+			assert.Equal(t, test.expectedType, actualType)
+			assert.Equal(t, test.expectedImports, actualImports)
+		})
+	}
+}
+
+func TestRun_ArrayColumns(t *testing.T) {
+	s := settings.New()
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "ids",
+				DataType:        "ARRAY",
+				UdtName:         "_int4",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "scores",
+				DataType:        "ARRAY",
+				UdtName:         "_float8",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "flags",
+				DataType:        "ARRAY",
+				UdtName:         "_bool",
+			},
+			{
+				OrdinalPosition: 4,
+				Name:            "tags",
+				DataType:        "ARRAY",
+				UdtName:         "_varchar",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 5,
+				Name:            "ranges",
+				DataType:        "ARRAY",
+				UdtName:         "_int4range",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "TestTable",
+		"package dto\n\nimport (\n\t\"github.com/lib/pq\"\n)\n\ntype TestTable struct {\nIDs pq.Int64Array `db:\"ids\"`\nScores pq.Float64Array `db:\"scores\"`\nFlags pq.BoolArray `db:\"flags\"`\nTags pq.StringArray `db:\"tags\"`\nRanges []byte `db:\"ranges\"`\n}")
+}
+
+func TestRun_EnumColumns(t *testing.T) {
+	tests := []struct {
+		desc       string
+		enumConsts bool
+		expected   string
+	}{
+		{
+			desc:       "default maps enum columns to string",
+			enumConsts: false,
+			expected:   "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nStatus string `db:\"status\"`\nPriority sql.NullString `db:\"priority\"`\n}",
+		},
+		{
+			desc:       "enabled enum-consts maps enum columns to named types with constants",
+			enumConsts: true,
+			expected: "package dto\n\ntype TestTable struct {\nStatus TestTableStatus `db:\"status\"`\nPriority *TestTablePriority `db:\"priority\"`\n}" +
+				"\n\ntype TestTableStatus string\n\nconst (\nTestTableStatusActive TestTableStatus = \"active\"\nTestTableStatusInProgress TestTableStatus = \"in progress\"\nTestTableStatus3 TestTableStatus = \"\"\n)" +
+				"\n\ntype TestTablePriority string\n\nconst (\nTestTablePriorityLow TestTablePriority = \"low\"\nTestTablePriorityHigh TestTablePriority = \"HIGH\"\n)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = settings.DBTypeMySQL
+			s.EnumConsts = test.enumConsts
+
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "status",
+						DataType:        "enum",
+						ColumnType:      "enum('active','in progress','')",
+						IsNullable:      "NO",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "priority",
+						DataType:        "enum",
+						ColumnType:      "enum('low','HIGH')",
+						IsNullable:      "YES",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, s, table, "TestTable", test.expected)
+		})
+	}
+}
+
+func TestRun_BitColumns(t *testing.T) {
+	for dbType := range settings.SupportedDbTypes {
+		t.Run(dbType.String(), func(t *testing.T) {
+
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+
+			for _, columnType := range db.GetBitDatatypes() {
+				t.Run(columnType, func(t *testing.T) {
+
+					newTable := func(length int64, isNullable string) *database.Table {
+						return &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition:        1,
+									Name:                   "column_name",
+									DataType:               columnType,
+									IsNullable:             isNullable,
+									CharacterMaximumLength: sql.NullInt64{Int64: length, Valid: length > 0},
+								},
+							},
+						}
+					}
+
+					tests := []struct {
+						desc     string
+						null     settings.NullType
+						table    *database.Table
+						expected string
+					}{
+						{
+							desc:     "single bit NOT NULL column",
+							null:     settings.NullTypeSQL,
+							table:    newTable(1, "NO"),
+							expected: "package dto\n\ntype TestTable struct {\nColumnName bool `db:\"column_name\"`\n}",
+						},
+						{
+							desc:     "single bit NULL column with sql null type",
+							null:     settings.NullTypeSQL,
+							table:    newTable(1, "YES"),
+							expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullBool `db:\"column_name\"`\n}",
+						},
+						{
+							desc:     "single bit NULL column with native null type",
+							null:     settings.NullTypeNative,
+							table:    newTable(1, "YES"),
+							expected: "package dto\n\ntype TestTable struct {\nColumnName *bool `db:\"column_name\"`\n}",
+						},
+						{
+							desc:     "multi bit NOT NULL column",
+							null:     settings.NullTypeSQL,
+							table:    newTable(8, "NO"),
+							expected: "package dto\n\ntype TestTable struct {\nColumnName []byte `db:\"column_name\"`\n}",
+						},
+						{
+							desc:     "multi bit NULL column",
+							null:     settings.NullTypeSQL,
+							table:    newTable(8, "YES"),
+							expected: "package dto\n\ntype TestTable struct {\nColumnName []byte `db:\"column_name\"`\n}",
+						},
+						{
+							desc:     "bit column without length",
+							null:     settings.NullTypeSQL,
+							table:    newTable(0, "NO"),
+							expected: "package dto\n\ntype TestTable struct {\nColumnName []byte `db:\"column_name\"`\n}",
+						},
+					}
+					for _, test := range tests {
+						t.Run(test.desc, func(t *testing.T) {
+							s := settings.New()
+							s.DbType = dbType
+							s.Null = test.null
+							assertRunWritesTable(t, s, test.table, "TestTable", test.expected)
+						})
+					}
+				})
+			}
+		})
+	}
+}
+
 func TestRun_UnknownColumns(t *testing.T) {
 	for dbType := range settings.SupportedDbTypes {
 		t.Run(dbType.String(), func(t *testing.T) {
@@ -1753,7 +3177,7 @@ func TestRun_UnknownColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -1844,7 +3268,7 @@ func TestRun_UnknownColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
 							)
 
 						err := Run(s, mdb, w)
@@ -1923,62 +3347,2170 @@ func TestRun_UnknownColumns(t *testing.T) {
 	}
 }
 
-func TestValidVariableName(t *testing.T) {
-	type testCase struct {
-		name     string
-		input    string
-		expected bool
+func TestRun_ForeignKeys(t *testing.T) {
+	newTable := func() *database.Table {
+		return &database.Table{
+			Name: "test_table",
+			Columns: []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "id",
+					DataType:        "integer",
+					IsNullable:      "NO",
+				},
+				{
+					OrdinalPosition: 2,
+					Name:            "user_id",
+					DataType:        "integer",
+					IsNullable:      "NO",
+				},
+				{
+					OrdinalPosition: 3,
+					Name:            "group_id",
+					DataType:        "integer",
+					IsNullable:      "NO",
+				},
+			},
+			ForeignKeys: []database.ForeignKey{
+				{ColumnName: "user_id", ReferencedTable: "user", ReferencedColumn: "id"},
+				{ColumnName: "group_id", ReferencedTable: "user_group"},
+			},
+		}
 	}
-	tests := []testCase{
-		{"basic", "MyVariable_2", true},
-		{"specialChars", "MyVar;iable", false},
-		{"brackets", "MyVariabl(e)", false},
-		{"nonEnglish", "MyVαriαble", true},
-		{"spaces", "My Variable", false},
-		{"whitespace", "My		Variable", false},
+
+	t.Run("foreign keys get annotated", func(t *testing.T) {
+		s := settings.New()
+		s.ForeignKeys = true
+
+		table := newTable()
+
+		mdb := newMockDb(database.New(s))
+		mdb.tables = append(mdb.tables, table)
+
+		mdb.
+			On("GetTables").
+			Return(mdb.tables, nil)
+		mdb.
+			On("PrepareGetColumnsOfTableStmt").
+			Return(nil)
+		mdb.
+			On("GetColumnsOfTable", table).
+			On("GetForeignKeysOfTable", table)
+
+		w := newMockWriter()
+		w.
+			On(
+				"Write",
+				"TestTable",
+				"package dto\n\ntype TestTable struct {\nID int `db:\"id\"`\n// FK -> user.id\nUserID int `db:\"user_id\"`\n// FK -> user_group\nGroupID int `db:\"group_id\"`\n}",
+			)
+
+		err := Run(s, mdb, w)
+		assert.NoError(t, err)
+		mdb.AssertExpectations(t)
+	})
+
+	t.Run("foreign keys are not selected by default", func(t *testing.T) {
+		s := settings.New()
+
+		table := newTable()
+		table.ForeignKeys = nil
+
+		assertRunWritesTable(t, s, table, "TestTable",
+			"package dto\n\ntype TestTable struct {\nID int `db:\"id\"`\nUserID int `db:\"user_id\"`\nGroupID int `db:\"group_id\"`\n}")
+	})
+}
+
+func TestRun_Comments(t *testing.T) {
+	tests := []struct {
+		desc     string
+		comments bool
+		expected string
+	}{
+		{
+			desc:     "default omits the comments of the columns",
+			expected: "package dto\n\ntype Users struct {\nID int `db:\"id\"`\nName string `db:\"name\"`\n}",
+		},
+		{
+			desc:     "enabled comments document the fields",
+			comments: true,
+			expected: "package dto\n\ntype Users struct {\n// Unique id.\n// Assigned by a sequence.\nID int `db:\"id\"`\nName string `db:\"name\"`\n}",
+		},
 	}
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			if validVariableName(tc.input) != tc.expected {
-				t.Errorf("TestValidVariableName(%q) should be %t", tc.input, tc.expected)
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Comments = test.comments
+
+			table := &database.Table{
+				Name: "users",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+						Comment:         sql.NullString{String: "Unique id.\n  Assigned by a sequence.", Valid: true},
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "name",
+						DataType:        "text",
+						Comment:         sql.NullString{String: "", Valid: true},
+					},
+				},
 			}
+
+			assertRunWritesTable(t, s, table, "Users", test.expected)
 		})
 	}
 }
 
-func TestReplaceSpace(t *testing.T) {
-	type testCase struct {
-		name     string
-		input    rune
-		expected rune
+func TestRun_BuildTags(t *testing.T) {
+	s := settings.New()
+	s.BuildTags = "integration && !windows"
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+		},
 	}
-	tests := []testCase{
-		{"letter", 'a', 'a'},
-		{"number", '7', '7'},
-		{"nonEnglish", '水', '水'},
-		{"space", ' ', '_'},
-		{"underscore", '_', '_'},
-		{"tab", '\t', '_'},
-		{"newline", '\n', '_'},
-		{"zeroWidthSpace", '​', '_'},
-		{"nonBreakingSpace", ' ', '_'},
+
+	assertRunWritesTable(t, s, table, "Users",
+		"//go:build integration && !windows\n// +build integration,!windows\n\n"+
+			"package dto\n\n"+
+			"type Users struct {\nID int `db:\"id\"`\n}")
+}
+
+func TestRun_NoDbTag(t *testing.T) {
+	s := settings.New()
+	s.TagsNoDb = true
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "name",
+				DataType:        "text",
+				IsNullable:      "NO",
+			},
+		},
 	}
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			output := replaceSpace(tc.input)
-			if output != tc.expected {
-				t.Errorf("replaceSpace(%q) = %q, expected %q", tc.input, output, tc.expected)
-			}
-		})
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\ntype Users struct {\nID int\nName string\n}")
+}
+
+func TestRun_TagsBun(t *testing.T) {
+	s := settings.New()
+	s.TagsNoDb = true
+	s.TagsBun = true
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				DefaultValue:    sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "name",
+				DataType:        "text",
+				IsNullable:      "NO",
+			},
+		},
 	}
 
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\nimport (\n\t\"github.com/uptrace/bun\"\n)\n\n"+
+			"type Users struct {\nbun.BaseModel `bun:\"table:users\"`\n\n"+
+			"ID int `bun:\"id,pk,autoincrement\"`\nName string `bun:\"name\"`\n}")
 }
 
-func TestFormatColumnName(t *testing.T) {
-	t.Run("pass", func(t *testing.T) {
-		type testCase struct {
-			name     string
-			input    string
+func TestRun_CompositePrimaryKey(t *testing.T) {
+	s := settings.New()
+	s.TagsGorm = true
+	s.TagsNoDb = true
+
+	// the first row of user_id belongs to a unique constraint, see ISSUE-4
+	table := &database.Table{
+		Name: "user_roles",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "user_id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				ConstraintType:  sql.NullString{String: "UNIQUE", Valid: true},
+			},
+			{
+				OrdinalPosition: 1,
+				Name:            "user_id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "role_id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				IsPrimaryKey:    true,
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "granted_by",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "UserRoles",
+		"package dto\n\ntype UserRoles struct {\n"+
+			"UserID int `gorm:\"column:user_id;primaryKey\"`\n"+
+			"RoleID int `gorm:\"column:role_id;primaryKey\"`\n"+
+			"GrantedBy int `gorm:\"column:granted_by\"`\n}")
+}
+
+func TestRun_SeparatedColumnNames(t *testing.T) {
+	s := settings.New()
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "user-id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "HTTPHost",
+				DataType:        "text",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\ntype Users struct {\nUserID int `db:\"user-id\"`\nHTTPHost string `db:\"HTTPHost\"`\n}")
+}
+
+func TestRun_Embed(t *testing.T) {
+	s := settings.New()
+	s.Embed = settings.List{"created_at", "updated_at"}
+	s.ScanHelper = true
+
+	newAuditColumns := func(position int) []database.Column {
+		return []database.Column{
+			{
+				OrdinalPosition: position,
+				Name:            "updated_at",
+				DataType:        "timestamp",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: position + 1,
+				Name:            "created_at",
+				DataType:        "timestamp",
+				IsNullable:      "NO",
+			},
+		}
+	}
+
+	users := &database.Table{
+		Name: "users",
+		Columns: append([]database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+		}, newAuditColumns(2)...),
+	}
+	posts := &database.Table{
+		Name: "posts",
+		Columns: append(newAuditColumns(1), database.Column{
+			OrdinalPosition: 3,
+			Name:            "title",
+			DataType:        "text",
+			IsNullable:      "NO",
+		}),
+	}
+	tags := &database.Table{
+		Name: "tags",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "created_at",
+				DataType:        "timestamp",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	// the columns of another nullability leave the table un-embedded
+	logs := &database.Table{
+		Name: "logs",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "created_at",
+				DataType:        "timestamp",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "updated_at",
+				DataType:        "timestamp",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	mdb := newMockDb(database.New(s))
+	mdb.tables = []*database.Table{users, posts, tags, logs}
+
+	// the columns of all tables are needed to find the embedded ones
+	mdb.On("GetTables")
+	mdb.On("GetColumnsOfTables", mock.Anything)
+
+	w := newMockWriter()
+	w.On("Write", "Users",
+		"package dto\n\ntype Users struct {\nID int `db:\"id\"`\nAuditFields\n}"+
+			"\n\nfunc (u *Users) ScanDest() []interface{} {\nreturn []interface{}{\n&u.ID,\n&u.UpdatedAt,\n&u.CreatedAt,\n}\n}")
+	w.On("Write", "Posts",
+		"package dto\n\ntype Posts struct {\nAuditFields\nTitle string `db:\"title\"`\n}"+
+			"\n\nfunc (p *Posts) ScanDest() []interface{} {\nreturn []interface{}{\n&p.UpdatedAt,\n&p.CreatedAt,\n&p.Title,\n}\n}")
+	w.On("Write", "Tags",
+		"package dto\n\nimport (\n\t\"time\"\n)\n\ntype Tags struct {\nCreatedAt time.Time `db:\"created_at\"`\n}"+
+			"\n\nfunc (t *Tags) ScanDest() []interface{} {\nreturn []interface{}{\n&t.CreatedAt,\n}\n}")
+	w.On("Write", "Logs",
+		"package dto\n\nimport (\n\t\"time\"\n)\n\ntype Logs struct {\nCreatedAt time.Time `db:\"created_at\"`\nUpdatedAt time.Time `db:\"updated_at\"`\n}"+
+			"\n\nfunc (l *Logs) ScanDest() []interface{} {\nreturn []interface{}{\n&l.CreatedAt,\n&l.UpdatedAt,\n}\n}")
+	w.On("Write", "AuditFields",
+		"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\n"+
+			"type AuditFields struct {\nCreatedAt time.Time `db:\"created_at\"`\nUpdatedAt sql.NullTime `db:\"updated_at\"`\n}")
+
+	err := Run(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_TagsValidate(t *testing.T) {
+	s := settings.New()
+	s.TagsValidate = true
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "age",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "active",
+				DataType:        "boolean",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "name",
+				DataType:        "text",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition:        4,
+				Name:                   "nickname",
+				DataType:               "character varying",
+				IsNullable:             "YES",
+				CharacterMaximumLength: sql.NullInt64{Int64: 32, Valid: true},
+			},
+		},
+	}
+
+	// the zero values of int and bool are valid values, unlike the empty
+	// string; the length of sql.NullString of the default null type is not
+	// validated
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\nimport (\n\t\"database/sql\"\n)\n\n"+
+			"type Users struct {\nAge int `db:\"age\"`\nActive bool `db:\"active\"`\n"+
+			"Name string `db:\"name\" validate:\"required\"`\nNickname sql.NullString `db:\"nickname\"`\n}")
+}
+
+func TestRun_FileNameFormat(t *testing.T) {
+	tests := []struct {
+		desc     string
+		format   settings.FileNameFormat
+		expected string
+	}{
+		{
+			desc:     "camel case file name",
+			format:   settings.FileNameFormatCamelCase,
+			expected: "UserAccount",
+		},
+		{
+			desc:     "snake case file name",
+			format:   settings.FileNameFormatSnakeCase,
+			expected: "user_account",
+		},
+		{
+			desc:     "original file name",
+			format:   settings.FileNameFormatOriginal,
+			expected: "user-Account",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.FileNameFormat = test.format
+
+			table := &database.Table{
+				Name: "user-Account",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+						IsNullable:      "NO",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, s, table, test.expected,
+				"package dto\n\ntype UserAccount struct {\nID int `db:\"id\"`\n}")
+		})
+	}
+}
+
+func TestRun_ColumnMap(t *testing.T) {
+	s := settings.New()
+	s.ColumnMap = true
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				ConstraintType:  sql.NullString{String: "UNIQUE", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "user_name",
+				DataType:        "text",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "USER_NAME",
+				DataType:        "text",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\ntype Users struct {\nID int `db:\"id\"`\nUserName string `db:\"user_name\"`\nUserName2 string `db:\"USER_NAME\"`\n}"+
+			"\n\nvar UsersColumns = struct {\nID string\nUserName string\nUserName2 string\n}{\nID: \"id\",\nUserName: \"user_name\",\nUserName2: \"USER_NAME\",\n}")
+}
+
+func TestRun_Constructor(t *testing.T) {
+	s := settings.New()
+	s.Constructor = true
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				DefaultValue:    sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "role",
+				DataType:        "character varying",
+				IsNullable:      "NO",
+				DefaultValue:    sql.NullString{String: "'guest'::character varying", Valid: true},
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "active",
+				DataType:        "boolean",
+				IsNullable:      "NO",
+				DefaultValue:    sql.NullString{String: "true", Valid: true},
+			},
+			{
+				OrdinalPosition: 4,
+				Name:            "logins",
+				DataType:        "integer",
+				IsNullable:      "YES",
+				DefaultValue:    sql.NullString{String: "0", Valid: true},
+			},
+			{
+				OrdinalPosition: 5,
+				Name:            "rating",
+				DataType:        "real",
+				IsNullable:      "NO",
+				DefaultValue:    sql.NullString{String: "2.5", Valid: true},
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\nimport (\n\t\"database/sql\"\n)\n\n"+
+			"type Users struct {\nID int `db:\"id\"`\nRole string `db:\"role\"`\nActive bool `db:\"active\"`\nLogins sql.NullInt64 `db:\"logins\"`\nRating float64 `db:\"rating\"`\n}"+
+			"\n\nfunc NewUsers() *Users {\nreturn &Users{\nRole: \"guest\",\nActive: true,\nRating: 2.5,\n}\n}")
+}
+
+func TestRun_Fixtures(t *testing.T) {
+	columns := []database.Column{
+		{
+			OrdinalPosition: 1,
+			Name:            "id",
+			DataType:        "integer",
+			IsNullable:      "NO",
+		},
+		{
+			OrdinalPosition: 2,
+			Name:            "name",
+			DataType:        "text",
+			IsNullable:      "NO",
+		},
+		{
+			OrdinalPosition: 3,
+			Name:            "active",
+			DataType:        "boolean",
+			IsNullable:      "NO",
+		},
+		{
+			OrdinalPosition: 4,
+			Name:            "created_at",
+			DataType:        "timestamp",
+			IsNullable:      "NO",
+		},
+		{
+			OrdinalPosition: 5,
+			Name:            "nickname",
+			DataType:        "character varying",
+			IsNullable:      "YES",
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc: "fields get sample values by their types",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Fixtures = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\n" +
+				"type Users struct {\nID int `db:\"id\"`\nName string `db:\"name\"`\nActive bool `db:\"active\"`\nCreatedAt time.Time `db:\"created_at\"`\nNickname sql.NullString `db:\"nickname\"`\n}" +
+				"\n\nfunc SampleUsers() Users {\nreturn Users{\nID: 0,\nName: \"\",\nActive: false,\nCreatedAt: time.Now(),\nNickname: sql.NullString{},\n}\n}",
+		},
+		{
+			desc: "pointers of unexported struct keep their zero value",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Fixtures = true
+				s.Unexported = true
+				s.TagsNoDb = true
+				s.Null = settings.NullTypePrimitive
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\n" +
+				"type users struct {\nid int\nname string\nactive bool\ncreatedAt time.Time\nnickname *string\n}" +
+				"\n\nfunc sampleUsers() users {\nreturn users{\nid: 0,\nname: \"\",\nactive: false,\ncreatedAt: time.Now(),\n}\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name:    "users",
+				Columns: columns,
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "Users", test.expected)
+		})
+	}
+}
+
+func TestDefaultLiteral(t *testing.T) {
+	tests := []struct {
+		desc            string
+		goType          string
+		defaultValue    string
+		expectedLiteral string
+		expectedOk      bool
+	}{
+		{desc: "quoted string", goType: "string", defaultValue: "'it''s'", expectedLiteral: `"it's"`, expectedOk: true},
+		{desc: "pg string with cast", goType: "string", defaultValue: "'abc'::text", expectedLiteral: `"abc"`, expectedOk: true},
+		{desc: "empty string is the zero value", goType: "string", defaultValue: "''"},
+		{desc: "unquoted string is no literal", goType: "string", defaultValue: "CURRENT_USER"},
+		{desc: "integer", goType: "int", defaultValue: "42", expectedLiteral: "42", expectedOk: true},
+		{desc: "sqlite integer in parentheses", goType: "int", defaultValue: "(-1)", expectedLiteral: "-1", expectedOk: true},
+		{desc: "zero integer", goType: "int", defaultValue: "0"},
+		{desc: "auto increment", goType: "int", defaultValue: "nextval('seq'::regclass)"},
+		{desc: "unsigned integer", goType: "uint64", defaultValue: "7", expectedLiteral: "7", expectedOk: true},
+		{desc: "float", goType: "float64", defaultValue: "'1.50'", expectedLiteral: "1.5", expectedOk: true},
+		{desc: "bool", goType: "bool", defaultValue: "true", expectedLiteral: "true", expectedOk: true},
+		{desc: "mysql bool", goType: "bool", defaultValue: "1", expectedLiteral: "true", expectedOk: true},
+		{desc: "false bool", goType: "bool", defaultValue: "false"},
+		{desc: "temporal", goType: "time.Time", defaultValue: "now()"},
+		{desc: "nullable type", goType: "sql.NullInt64", defaultValue: "1"},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actualLiteral, actualOk := defaultLiteral(test.goType, test.defaultValue)
+			assert.Equal(t, test.expectedLiteral, actualLiteral)
+			assert.Equal(t, test.expectedOk, actualOk)
+		})
+	}
+}
+
+func TestRun_SQL(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		table    *database.Table
+		expected string
+	}{
+		{
+			desc: "pg uses numbered placeholders and skips auto increment columns on insert",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.SQL = true
+				return s
+			},
+			table: &database.Table{
+				Name: "users",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+						IsNullable:      "NO",
+						DefaultValue:    sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+						ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "name",
+						DataType:        "text",
+						IsNullable:      "NO",
+					},
+					{
+						OrdinalPosition: 3,
+						Name:            "Email",
+						DataType:        "text",
+						IsNullable:      "NO",
+					},
+				},
+			},
+			expected: "package dto\n\ntype Users struct {\nID int `db:\"id\"`\nName string `db:\"name\"`\nEmail string `db:\"Email\"`\n}" +
+				"\n\nconst (\n" +
+				"UsersSelectAll = \"SELECT id, name, \\\"Email\\\" FROM users\"\n" +
+				"UsersInsert = \"INSERT INTO users (name, \\\"Email\\\") VALUES ($1, $2)\"\n" +
+				"UsersUpdate = \"UPDATE users SET name = $1, \\\"Email\\\" = $2 WHERE id = $3\"\n" +
+				")",
+		},
+		{
+			desc: "mysql uses question mark placeholders and composite primary keys",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.SQL = true
+				return s
+			},
+			table: &database.Table{
+				Name: "user_roles",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "user_id",
+						DataType:        "int",
+						IsNullable:      "NO",
+						ColumnKey:       "PRI",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "role_id",
+						DataType:        "int",
+						IsNullable:      "NO",
+						ColumnKey:       "PRI",
+					},
+					{
+						OrdinalPosition: 3,
+						Name:            "granted_by",
+						DataType:        "int",
+						IsNullable:      "NO",
+					},
+				},
+			},
+			expected: "package dto\n\ntype UserRoles struct {\nUserID int `db:\"user_id\"`\nRoleID int `db:\"role_id\"`\nGrantedBy int `db:\"granted_by\"`\n}" +
+				"\n\nconst (\n" +
+				"UserRolesSelectAll = \"SELECT user_id, role_id, granted_by FROM user_roles\"\n" +
+				"UserRolesInsert = \"INSERT INTO user_roles (user_id, role_id, granted_by) VALUES (?, ?, ?)\"\n" +
+				"UserRolesUpdate = \"UPDATE user_roles SET granted_by = ? WHERE user_id = ? AND role_id = ?\"\n" +
+				")",
+		},
+		{
+			desc: "sqlite keeps a text primary key on insert",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeSQLite
+				s.SQL = true
+				return s
+			},
+			table: &database.Table{
+				Name: "codes",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 0,
+						Name:            "code",
+						DataType:        "text",
+						IsNullable:      "NO",
+						ColumnKey:       "PK",
+						IsPrimaryKey:    true,
+					},
+					{
+						OrdinalPosition: 1,
+						Name:            "label",
+						DataType:        "text",
+						IsNullable:      "NO",
+					},
+				},
+			},
+			expected: "package dto\n\ntype Codes struct {\nCode string `db:\"code\"`\nLabel string `db:\"label\"`\n}" +
+				"\n\nconst (\n" +
+				"CodesSelectAll = \"SELECT code, label FROM codes\"\n" +
+				"CodesInsert = \"INSERT INTO codes (code, label) VALUES (?, ?)\"\n" +
+				"CodesUpdate = \"UPDATE codes SET label = ? WHERE code = ?\"\n" +
+				")",
+		},
+		{
+			desc: "table of a schema is qualified by the schema",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Schema = "public,Audit"
+				s.SQL = true
+				return s
+			},
+			table: &database.Table{
+				Name:   "users",
+				Schema: "Audit",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+						IsNullable:      "NO",
+						ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "name",
+						DataType:        "text",
+						IsNullable:      "NO",
+					},
+				},
+			},
+			expected: "package dto\n\ntype AuditUsers struct {\nID int `db:\"id\"`\nName string `db:\"name\"`\n}" +
+				"\n\nconst (\n" +
+				"AuditUsersSelectAll = \"SELECT id, name FROM \\\"Audit\\\".users\"\n" +
+				"AuditUsersInsert = \"INSERT INTO \\\"Audit\\\".users (id, name) VALUES ($1, $2)\"\n" +
+				"AuditUsersUpdate = \"UPDATE \\\"Audit\\\".users SET name = $1 WHERE id = $2\"\n" +
+				")",
+		},
+		{
+			desc: "pg inserts the defaults if all columns are auto increment columns",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.SQL = true
+				return s
+			},
+			table: &database.Table{
+				Name: "tickets",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+						IsNullable:      "NO",
+						DefaultValue:    sql.NullString{String: "nextval('tickets_id_seq'::regclass)", Valid: true},
+						ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+					},
+				},
+			},
+			expected: "package dto\n\ntype Tickets struct {\nID int `db:\"id\"`\n}" +
+				"\n\nconst (\n" +
+				"TicketsSelectAll = \"SELECT id FROM tickets\"\n" +
+				"TicketsInsert = \"INSERT INTO tickets DEFAULT VALUES\"\n" +
+				")",
+		},
+		{
+			desc: "mysql inserts empty lists if all columns are auto increment columns",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.SQL = true
+				return s
+			},
+			table: &database.Table{
+				Name: "tickets",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "int",
+						IsNullable:      "NO",
+						ColumnKey:       "PRI",
+						Extra:           "auto_increment",
+					},
+				},
+			},
+			expected: "package dto\n\ntype Tickets struct {\nID int `db:\"id\"`\n}" +
+				"\n\nconst (\n" +
+				"TicketsSelectAll = \"SELECT id FROM tickets\"\n" +
+				"TicketsInsert = \"INSERT INTO tickets () VALUES ()\"\n" +
+				")",
+		},
+		{
+			desc: "without primary key the update is omitted",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.SQL = true
+				return s
+			},
+			table: &database.Table{
+				Name: "logs",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "message",
+						DataType:        "text",
+						IsNullable:      "NO",
+					},
+				},
+			},
+			expected: "package dto\n\ntype Logs struct {\nMessage string `db:\"message\"`\n}" +
+				"\n\nconst (\n" +
+				"LogsSelectAll = \"SELECT message FROM logs\"\n" +
+				"LogsInsert = \"INSERT INTO logs (message) VALUES ($1)\"\n" +
+				")",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			fileName := camelCaseString(test.table.Name)
+			if test.table.Schema != "" {
+				fileName = camelCaseString(test.table.Schema + "_" + test.table.Name)
+			}
+			assertRunWritesTable(t, test.settings(), test.table, fileName, test.expected)
+		})
+	}
+}
+
+func TestRun_DDLComment(t *testing.T) {
+	s := settings.New()
+	s.DDLComment = true
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				DefaultValue:    sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				DefaultValue:    sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+				ConstraintType:  sql.NullString{String: "UNIQUE", Valid: true},
+			},
+			{
+				OrdinalPosition:        2,
+				Name:                   "name",
+				DataType:               "character varying",
+				IsNullable:             "YES",
+				CharacterMaximumLength: sql.NullInt64{Int64: 255, Valid: true},
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\nimport (\n\t\"database/sql\"\n)\n\n"+
+			"// CREATE TABLE users (\n"+
+			"//   id integer NOT NULL DEFAULT nextval('users_id_seq'::regclass),\n"+
+			"//   name character varying(255),\n"+
+			"//   PRIMARY KEY (id)\n"+
+			"// )\n"+
+			"type Users struct {\nID int `db:\"id\"`\nName sql.NullString `db:\"name\"`\n}")
+}
+
+func TestRun_Defaults(t *testing.T) {
+	newTable := func() *database.Table {
+		return &database.Table{
+			Name: "test_table",
+			Columns: []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "id",
+					DataType:        "integer",
+					DefaultValue:    sql.NullString{String: "nextval('test_table_id_seq'::regclass)", Valid: true},
+				},
+				{
+					OrdinalPosition: 2,
+					Name:            "counter",
+					DataType:        "integer",
+					DefaultValue:    sql.NullString{String: "0", Valid: true},
+				},
+				{
+					OrdinalPosition: 3,
+					Name:            "note",
+					DataType:        "text",
+					DefaultValue:    sql.NullString{String: "'multi\nline'::text", Valid: true},
+				},
+				{
+					OrdinalPosition: 4,
+					Name:            "name",
+					DataType:        "text",
+				},
+			},
+		}
+	}
+
+	t.Run("default values are not annotated by default", func(t *testing.T) {
+		s := settings.New()
+		assertRunWritesTable(t, s, newTable(), "TestTable",
+			"package dto\n\ntype TestTable struct {\nID int `db:\"id\"`\nCounter int `db:\"counter\"`\nNote string `db:\"note\"`\nName string `db:\"name\"`\n}")
+	})
+
+	t.Run("default values get annotated", func(t *testing.T) {
+		s := settings.New()
+		s.Defaults = true
+		assertRunWritesTable(t, s, newTable(), "TestTable",
+			"package dto\n\ntype TestTable struct {\nID int `db:\"id\"` // default: nextval('test_table_id_seq'::regclass)\nCounter int `db:\"counter\"` // default: 0\nNote string `db:\"note\"` // default: 'multi line'::text\nName string `db:\"name\"`\n}")
+	})
+}
+
+func TestRun_PKFirst(t *testing.T) {
+	newTable := func() *database.Table {
+		return &database.Table{
+			Name: "test_table",
+			Columns: []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "name",
+					DataType:        "text",
+				},
+				{
+					OrdinalPosition: 2,
+					Name:            "tenant_id",
+					DataType:        "integer",
+					ConstraintType:  sql.NullString{String: "FOREIGN KEY", Valid: true},
+				},
+				{
+					OrdinalPosition: 2,
+					Name:            "tenant_id",
+					DataType:        "integer",
+					ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+				},
+				{
+					OrdinalPosition: 3,
+					Name:            "created",
+					DataType:        "integer",
+				},
+				{
+					OrdinalPosition: 4,
+					Name:            "id",
+					DataType:        "integer",
+					ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+				},
+			},
+		}
+	}
+
+	t.Run("order of columns is kept by default", func(t *testing.T) {
+		s := settings.New()
+		assertRunWritesTable(t, s, newTable(), "TestTable",
+			"package dto\n\ntype TestTable struct {\nName string `db:\"name\"`\nTenantID int `db:\"tenant_id\"`\nCreated int `db:\"created\"`\nID int `db:\"id\"`\n}")
+	})
+
+	t.Run("primary key columns come first", func(t *testing.T) {
+		s := settings.New()
+		s.PKFirst = true
+		assertRunWritesTable(t, s, newTable(), "TestTable",
+			"package dto\n\ntype TestTable struct {\nTenantID int `db:\"tenant_id\"`\nID int `db:\"id\"`\nName string `db:\"name\"`\nCreated int `db:\"created\"`\n}")
+	})
+}
+
+func TestRun_ScanHelper(t *testing.T) {
+	s := settings.New()
+	s.ScanHelper = true
+	s.PKFirst = true
+	s.TableConst = settings.TableConstMethod
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "name",
+				DataType:        "text",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "id",
+				DataType:        "integer",
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\ntype Users struct {\nID int `db:\"id\"`\nName string `db:\"name\"`\n}"+
+			"\n\nfunc (u *Users) ScanDest() []interface{} {\nreturn []interface{}{\n&u.Name,\n&u.ID,\n}\n}"+
+			"\n\nfunc (Users) TableName() string {\nreturn \"users\"\n}")
+}
+
+func TestCreateTableStructString_MethodCollidesWithField(t *testing.T) {
+	tests := []struct {
+		desc          string
+		settings      func(s *settings.Settings)
+		columns       []string
+		expectedError string
+	}{
+		{
+			desc:          "ScanDest",
+			settings:      func(s *settings.Settings) { s.ScanHelper = true },
+			columns:       []string{"scan_dest"},
+			expectedError: `field "ScanDest" collides with the method ScanDest() of -scanhelper, rename it by -rename`,
+		},
+		{
+			desc:          "ScanRow",
+			settings:      func(s *settings.Settings) { s.Null = settings.NullTypeZero },
+			columns:       []string{"scan_row"},
+			expectedError: `field "ScanRow" collides with the method ScanRow() of -null zero, rename it by -rename`,
+		},
+		{
+			desc:          "Fields",
+			settings:      func(s *settings.Settings) { s.FieldMeta = true },
+			columns:       []string{"fields"},
+			expectedError: `field "Fields" collides with the method Fields() of -fieldmeta, rename it by -rename`,
+		},
+		{
+			desc:          "getter",
+			settings:      func(s *settings.Settings) { s.Getters = true },
+			columns:       []string{"name", "get_name"},
+			expectedError: `field "GetName" collides with the method GetName() of -getters, rename it by -rename`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			test.settings(s)
+
+			table := &database.Table{Name: "jobs"}
+			for i, name := range test.columns {
+				table.Columns = append(table.Columns, database.Column{
+					OrdinalPosition: i + 1,
+					Name:            name,
+					DataType:        "text",
+					IsNullable:      "YES",
+				})
+			}
+
+			_, _, err := createTableStructString(s, quietLog, tagger.NewTaggers(s), database.New(s), table, nil, false)
+			assert.EqualError(t, err, test.expectedError)
+
+			// unexported fields never collide with the exported methods
+			s.Unexported = true
+			_, _, err = createTableStructString(s, quietLog, tagger.NewTaggers(s), database.New(s), table, nil, false)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRun_OrdinalPositionGaps(t *testing.T) {
+	s := settings.New()
+	s.ScanHelper = true
+	s.PKFirst = true
+
+	// columns 2 and 3 were dropped
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "name",
+				DataType:        "text",
+			},
+			{
+				OrdinalPosition: 4,
+				Name:            "id",
+				DataType:        "integer",
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 7,
+				Name:            "email",
+				DataType:        "text",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\ntype Users struct {\nID int `db:\"id\"`\nName string `db:\"name\"`\nEmail string `db:\"email\"`\n}"+
+			"\n\nfunc (u *Users) ScanDest() []interface{} {\nreturn []interface{}{\n&u.Name,\n&u.ID,\n&u.Email,\n}\n}")
+}
+
+func TestRun_Getters(t *testing.T) {
+	s := settings.New()
+	s.Getters = true
+	s.TableConst = settings.TableConstMethod
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "name",
+				DataType:        "text",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype Users struct {\nID int `db:\"id\"`\nName sql.NullString `db:\"name\"`\n}"+
+			"\n\nfunc (u Users) GetID() int {\nreturn u.ID\n}"+
+			"\n\nfunc (u Users) GetName() sql.NullString {\nreturn u.Name\n}"+
+			"\n\ntype UsersGetter interface {\nGetID() int\nGetName() sql.NullString\n}"+
+			"\n\nfunc (Users) TableName() string {\nreturn \"users\"\n}")
+}
+
+func TestRun_Rename(t *testing.T) {
+	s := settings.New()
+	s.Initialisms = true
+	s.Rename = settings.Map{"old_col": "NewName", "users.other_col": "Other"}
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "api_key",
+				DataType:        "text",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "old_col",
+				DataType:        "text",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "other_col",
+				DataType:        "text",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\ntype Users struct {\nAPIKey string `db:\"api_key\"`\n"+
+			"NewName string `db:\"old_col\"`\nOther string `db:\"other_col\"`\n}")
+}
+
+func TestRun_NullTypeZero(t *testing.T) {
+	scanRow := "\n\nfunc (u *Users) ScanRow(row interface{ Scan(dest ...interface{}) error }) error {\n" +
+		"var nullableAge *int\nvar nullableDeletedAt *time.Time\n" +
+		"if err := row.Scan(&u.ID, &nullableAge, &nullableDeletedAt, &u.Name); err != nil {\nreturn err\n}\n" +
+		"if nullableAge != nil {\nu.Age, u.AgeValid = *nullableAge, true\n} else {\nu.Age, u.AgeValid = 0, false\n}\n" +
+		"if nullableDeletedAt != nil {\nu.DeletedAt, u.DeletedAtValid = *nullableDeletedAt, true\n} else {\nu.DeletedAt, u.DeletedAtValid = time.Time{}, false\n}\n" +
+		"return nil\n}"
+
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc: "nullable columns get zero values along with their validity",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Null = settings.NullTypeZero
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\n" +
+				"type Users struct {\nID int `db:\"id\"`\nAge int `db:\"age\"`\nAgeValid bool `db:\"-\"`\n" +
+				"DeletedAt time.Time `db:\"deleted_at\"`\nDeletedAtValid bool `db:\"-\"`\nName string `db:\"name\"`\n}" + scanRow,
+		},
+		{
+			desc: "scan helper is left out for ScanRow setting the validity",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Null = settings.NullTypeZero
+				s.ScanHelper = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\n" +
+				"type Users struct {\nID int `db:\"id\"`\nAge int `db:\"age\"`\nAgeValid bool `db:\"-\"`\n" +
+				"DeletedAt time.Time `db:\"deleted_at\"`\nDeletedAtValid bool `db:\"-\"`\nName string `db:\"name\"`\n}" + scanRow,
+		},
+		{
+			desc: "validity is ignored by gorm and without db-tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Null = settings.NullTypeZero
+				s.TagsNoDb = true
+				s.TagsGorm = true
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\n" +
+				"type Users struct {\nID int `gorm:\"column:id\"`\nAge int `gorm:\"column:age\"`\nAgeValid bool `gorm:\"-\"`\n" +
+				"DeletedAt time.Time `gorm:\"column:deleted_at\"`\nDeletedAtValid bool `gorm:\"-\"`\nName string `gorm:\"column:name\"`\n}" + scanRow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "users",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+						IsNullable:      "NO",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "age",
+						DataType:        "integer",
+						IsNullable:      "YES",
+					},
+					{
+						OrdinalPosition: 3,
+						Name:            "deleted_at",
+						DataType:        "timestamp",
+						IsNullable:      "YES",
+					},
+					{
+						OrdinalPosition: 4,
+						Name:            "name",
+						DataType:        "text",
+						IsNullable:      "NO",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "Users", test.expected)
+		})
+	}
+}
+
+// scanDriverSource is a database/sql driver returning the given rows for any
+// query, so the generated code can be run against the conversions of
+// database/sql without a database.
+const scanDriverSource = `package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+type scanDriver struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (d scanDriver) Open(string) (driver.Conn, error) { return scanConn{d}, nil }
+
+type scanConn struct{ d scanDriver }
+
+func (c scanConn) Prepare(string) (driver.Stmt, error) { return scanStmt{c.d}, nil }
+func (scanConn) Close() error                          { return nil }
+func (scanConn) Begin() (driver.Tx, error)             { return nil, errors.New("not supported") }
+
+type scanStmt struct{ d scanDriver }
+
+func (scanStmt) Close() error                                 { return nil }
+func (scanStmt) NumInput() int                                { return -1 }
+func (scanStmt) Exec([]driver.Value) (driver.Result, error)  { return nil, errors.New("not supported") }
+func (s scanStmt) Query([]driver.Value) (driver.Rows, error) { return &scanRows{d: s.d}, nil }
+
+type scanRows struct {
+	d scanDriver
+	i int
+}
+
+func (r *scanRows) Columns() []string { return r.d.columns }
+func (*scanRows) Close() error        { return nil }
+func (r *scanRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.d.rows) {
+		return io.EOF
+	}
+	copy(dest, r.d.rows[r.i])
+	r.i++
+	return nil
+}
+
+func openScanDB(columns []string, rows ...[]driver.Value) *sql.DB {
+	sql.Register("scan", scanDriver{columns: columns, rows: rows})
+	db, err := sql.Open("scan", "")
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+`
+
+// runGenerated runs the given files of package main, the generated ones along
+// with a main function, and returns the output.
+func runGenerated(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping run of generated code in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("skipping run of generated code: %v", err)
+	}
+
+	dir := t.TempDir()
+	files["go.mod"] = "module scantest\n\ngo 1.18\n"
+	files["driver.go"] = scanDriverSource
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=off", "GOPROXY=off")
+	actual, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("expected non error, got: %s\n%s", err, actual)
+	}
+
+	return string(actual)
+}
+
+func TestRun_NullTypeZeroScanRow(t *testing.T) {
+	s := settings.New()
+	s.Null = settings.NullTypeZero
+	s.PackageName = "main"
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "age",
+				DataType:        "integer",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "nick",
+				DataType:        "text",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	_, content, err := createTableStructString(s, quietLog, tagger.NewTaggers(s), database.New(s), table, nil, false)
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	actual := runGenerated(t, map[string]string{
+		"users.go": content,
+		"main.go": `package main
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+func main() {
+	db := openScanDB([]string{"id", "age", "nick"},
+		[]driver.Value{int64(1), nil, nil},
+		[]driver.Value{int64(2), int64(42), "bob"},
+	)
+	rows, err := db.Query("SELECT id, age, nick FROM users")
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	// the NULLs of the first row reset the fields set before
+	u := Users{Age: 7, AgeValid: true, Nick: "x", NickValid: true}
+	for rows.Next() {
+		if err := u.ScanRow(rows); err != nil {
+			panic(err)
+		}
+		fmt.Println(u.ID, u.Age, u.AgeValid, u.Nick, u.NickValid)
+	}
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+}
+`,
+	})
+
+	assert.Equal(t, "1 0 false  false\n2 42 true bob true\n", actual)
+}
+
+func TestRun_TypeOverride(t *testing.T) {
+	s := settings.New()
+	s.TypeOverride = settings.Map{"status": "Status", "users.ip": "*github.com/example/types.IP", "posts.ip": "[]byte"}
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "status",
+				DataType:        "smallint",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "ip",
+				DataType:        "inet",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\nimport (\n\t\"github.com/example/types\"\n)\n\n"+
+			"type Users struct {\nStatus Status `db:\"status\"`\nIp *types.IP `db:\"ip\"`\n}")
+}
+
+func TestRun_TableConst(t *testing.T) {
+	tests := []struct {
+		desc       string
+		tableConst settings.TableConst
+		expected   string
+	}{
+		{
+			desc:       "disabled by default",
+			tableConst: settings.TableConstNone,
+			expected:   "package dto\n\ntype PreUsersSuf struct {\nID int `db:\"id\"`\n}",
+		},
+		{
+			desc:       "method returns the name of the table",
+			tableConst: settings.TableConstMethod,
+			expected:   "package dto\n\ntype PreUsersSuf struct {\nID int `db:\"id\"`\n}\n\nfunc (PreUsersSuf) TableName() string {\nreturn \"users\"\n}",
+		},
+		{
+			desc:       "const holds the name of the table",
+			tableConst: settings.TableConstConst,
+			expected:   "package dto\n\ntype PreUsersSuf struct {\nID int `db:\"id\"`\n}\n\nconst TableNamePreUsersSuf = \"users\"",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Prefix = "pre_"
+			s.Suffix = "_suf"
+			s.TableConst = test.tableConst
+
+			table := &database.Table{
+				Name: "users",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, s, table, "PreUsersSuf", test.expected)
+		})
+	}
+
+	t.Run("method colliding with a field fails", func(t *testing.T) {
+		s := settings.New()
+		s.TableConst = settings.TableConstMethod
+
+		table := &database.Table{
+			Name: "jobs",
+			Columns: []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "table_name",
+					DataType:        "text",
+				},
+			},
+		}
+
+		_, _, err := createTableStructString(s, quietLog, tagger.NewTaggers(s), database.New(s), table, nil, false)
+		assert.EqualError(t, err, `field "TableName" collides with the method TableName() of -tableconst method, rename it by -rename`)
+
+		// the const does not collide
+		s.TableConst = settings.TableConstConst
+		assertRunWritesTable(t, s, table, "Jobs",
+			"package dto\n\ntype Jobs struct {\nTableName string `db:\"table_name\"`\n}\n\nconst TableNameJobs = \"jobs\"")
+	})
+}
+
+func TestRun_TrimPrefix(t *testing.T) {
+	tests := []struct {
+		desc       string
+		schema     string
+		tableName  string
+		structName string
+		expected   string
+	}{
+		{
+			desc:       "prefix gets trimmed from the struct name but not from the table name",
+			tableName:  "tbl_users",
+			structName: "PreUsers",
+			expected:   "package dto\n\ntype PreUsers struct {\nID int `db:\"id\"`\n}\n\nfunc (PreUsers) TableName() string {\nreturn \"tbl_users\"\n}",
+		},
+		{
+			desc:       "table without the prefix keeps its name",
+			tableName:  "users",
+			structName: "PreUsers",
+			expected:   "package dto\n\ntype PreUsers struct {\nID int `db:\"id\"`\n}\n\nfunc (PreUsers) TableName() string {\nreturn \"users\"\n}",
+		},
+		{
+			desc:       "table named like the prefix keeps its name",
+			tableName:  "tbl_",
+			structName: "PreTbl",
+			expected:   "package dto\n\ntype PreTbl struct {\nID int `db:\"id\"`\n}\n\nfunc (PreTbl) TableName() string {\nreturn \"tbl_\"\n}",
+		},
+		{
+			desc:       "prefix gets trimmed in front of the schema of multiple schemas",
+			schema:     "audit",
+			tableName:  "tbl_users",
+			structName: "PreAuditUsers",
+			expected:   "package dto\n\ntype PreAuditUsers struct {\nID int `db:\"id\"`\n}\n\nfunc (PreAuditUsers) TableName() string {\nreturn \"tbl_users\"\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Prefix = "pre_"
+			s.TrimPrefix = "tbl_"
+			s.TableConst = settings.TableConstMethod
+			if test.schema != "" {
+				s.Schema = "public," + test.schema
+			}
+
+			table := &database.Table{
+				Name:   test.tableName,
+				Schema: test.schema,
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, s, table, test.structName, test.expected)
+		})
+	}
+}
+
+func TestRun_LeadingDigitNames(t *testing.T) {
+	table := &database.Table{
+		Name: "1st_place",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "1st_place",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "2fa_enabled",
+				DataType:        "boolean",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	t.Run("camelcase", func(t *testing.T) {
+		s := settings.New()
+		assertRunWritesTable(t, s, table, "X1stPlace",
+			"package dto\n\ntype X1stPlace struct {\nX1stPlace int `db:\"1st_place\"`\nX2faEnabled bool `db:\"2fa_enabled\"`\n}")
+	})
+
+	t.Run("original", func(t *testing.T) {
+		s := settings.New()
+		s.OutputFormat = settings.OutputFormatOriginal
+		assertRunWritesTable(t, s, table, "X1stPlace",
+			"package dto\n\ntype X_1st_place struct {\nX_1st_place int `db:\"1st_place\"`\nX_2fa_enabled bool `db:\"2fa_enabled\"`\n}")
+	})
+}
+
+func TestRun_SubDirs(t *testing.T) {
+	tests := []struct {
+		desc     string
+		subDirs  bool
+		schema   string
+		fileName string
+	}{
+		{
+			desc:     "default writes the file flat",
+			schema:   "public",
+			fileName: "Users",
+		},
+		{
+			desc:     "sub directories write the file into the schema directory",
+			subDirs:  true,
+			schema:   "public",
+			fileName: "public/Users",
+		},
+		{
+			desc:     "sub directories write the file flat without schema",
+			subDirs:  true,
+			fileName: "Users",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.SubDirs = test.subDirs
+
+			table := &database.Table{
+				Name:   "users",
+				Schema: test.schema,
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, s, table, test.fileName,
+				"package dto\n\ntype Users struct {\nID int `db:\"id\"`\n}")
+		})
+	}
+}
+
+func TestRun_CollidingColumnNames(t *testing.T) {
+	s := settings.New()
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "user_id",
+				DataType:        "integer",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "USER_ID",
+				DataType:        "integer",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "user_id2",
+				DataType:        "integer",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "Users",
+		"package dto\n\ntype Users struct {\nUserID int `db:\"user_id\"`\n"+
+			"UserID2 int `db:\"USER_ID\"`\nUserID22 int `db:\"user_id2\"`\n}")
+}
+
+func TestRun_MultipleSchemas(t *testing.T) {
+	tests := []struct {
+		desc     string
+		subDirs  bool
+		fileName string
+		content  string
+	}{
+		{
+			desc:     "structs of multiple schemas get prefixed by the schema",
+			fileName: "AuditUsers",
+			content:  "package dto\n\ntype AuditUsers struct {\nID int `db:\"id\"`\n}",
+		},
+		{
+			desc:     "structs in sub directories do not get prefixed",
+			subDirs:  true,
+			fileName: "audit/Users",
+			content:  "package dto\n\ntype Users struct {\nID int `db:\"id\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Schema = "public,audit"
+			s.SubDirs = test.subDirs
+
+			table := &database.Table{
+				Name:   "users",
+				Schema: "audit",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "id",
+						DataType:        "integer",
+					},
+				},
+			}
+
+			assertRunWritesTable(t, s, table, test.fileName, test.content)
+		})
+	}
+}
+
+func TestRun_FilterTables(t *testing.T) {
+	s := settings.New()
+	s.Include = settings.List{"user_*", "order_*"}
+	s.Exclude = settings.List{"*_tmp"}
+
+	mdb := newMockDb(database.New(s))
+	for _, name := range []string{"user_account", "user_tmp", "order_item", "product"} {
+		mdb.tables = append(mdb.tables, &database.Table{
+			Name: name,
+			Columns: []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "id",
+					DataType:        "integer",
+				},
+			},
+		})
+	}
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", mdb.tables[0]).
+		On("GetColumnsOfTable", mdb.tables[2])
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"UserAccount",
+			"package dto\n\ntype UserAccount struct {\nID int `db:\"id\"`\n}",
+		).
+		On(
+			"Write",
+			"OrderItem",
+			"package dto\n\ntype OrderItem struct {\nID int `db:\"id\"`\n}",
+		)
+
+	err := Run(s, mdb, w)
+	assert.NoError(t, err)
+	mdb.AssertExpectations(t)
+	w.AssertExpectations(t)
+}
+
+func TestRun_FilterTablesCaseInsensitive(t *testing.T) {
+	s := settings.New()
+	s.Include = settings.List{"useraccounts"}
+	s.CITables = true
+
+	// the columns get queried by the name as stored by the database
+	table := &database.Table{
+		Name: "UserAccounts",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+		},
+	}
+
+	mdb := newMockDb(database.New(s))
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"UserAccounts",
+			"package dto\n\ntype UserAccounts struct {\nID int `db:\"id\"`\n}",
+		)
+
+	err := Run(s, mdb, w)
+	assert.NoError(t, err)
+	mdb.AssertExpectations(t)
+	w.AssertExpectations(t)
+}
+
+func TestRun_Routines(t *testing.T) {
+	s := settings.New()
+	s.Routines = true
+	s.TableConst = settings.TableConstMethod
+
+	routine := &database.Table{
+		Name:    "active_users",
+		Routine: true,
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	mdb := newMockDb(database.New(s))
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("GetRoutines").
+		Return([]*database.Table{routine})
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"ActiveUsersResult",
+			"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype ActiveUsersResult struct {\nID sql.NullInt64 `db:\"id\"`\n}",
+		)
+
+	err := Run(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+	mdb.AssertNotCalled(t, "GetColumnsOfTable", routine)
+}
+
+func TestRun_NoOverwrite(t *testing.T) {
+	s := settings.New()
+	s.OutputFilePath = t.TempDir()
+
+	mdb := newMockDb(database.New(s))
+	for _, name := range []string{"customized", "generated"} {
+		mdb.tables = append(mdb.tables, &database.Table{
+			Name: name,
+			Columns: []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "id",
+					DataType:        "integer",
+				},
+			},
+		})
+	}
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	for _, table := range mdb.tables {
+		mdb.On("GetColumnsOfTable", table)
+	}
+
+	customized := filepath.Join(s.OutputFilePath, "Customized"+output.FileWriterExtension)
+	if err := os.WriteFile(customized, []byte("package dto\n"), 0666); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	w := output.NewFileWriter(s.OutputFilePath)
+	w.SetOverwrite(false)
+
+	err := Run(s, mdb, w)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(customized)
+	assert.NoError(t, err)
+	assert.Equal(t, "package dto\n", string(content))
+
+	_, err = os.Stat(filepath.Join(s.OutputFilePath, "Generated"+output.FileWriterExtension))
+	assert.NoError(t, err)
+}
+
+func TestRun_Concurrency(t *testing.T) {
+	newTables := func(n int) []*database.Table {
+		tables := make([]*database.Table, 0, n)
+		for i := 1; i <= n; i++ {
+			tables = append(tables, &database.Table{
+				Name: fmt.Sprintf("test_table_%d", i),
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "column_name",
+						DataType:        "integer",
+						IsNullable:      "NO",
+					},
+				},
+			})
+		}
+		return tables
+	}
+
+	for _, concurrency := range []int{1, 4, 32} {
+		t.Run(fmt.Sprintf("%d workers write all tables", concurrency), func(t *testing.T) {
+			s := settings.New()
+			s.Concurrency = concurrency
+
+			mdb := newMockDb(database.New(s))
+			mdb.tables = newTables(20)
+
+			mdb.
+				On("GetTables").
+				Return(mdb.tables, nil)
+			mdb.
+				On("PrepareGetColumnsOfTableStmt").
+				Return(nil)
+
+			w := newMockWriter()
+			for i, table := range mdb.tables {
+				mdb.On("GetColumnsOfTable", table)
+				w.On(
+					"Write",
+					fmt.Sprintf("TestTable%d", i+1),
+					fmt.Sprintf("package dto\n\ntype TestTable%d struct {\nColumnName int `db:\"column_name\"`\n}", i+1),
+				)
+			}
+
+			err := Run(s, mdb, w)
+			assert.NoError(t, err)
+			w.AssertExpectations(t)
+		})
+	}
+
+	t.Run("error of a table gets returned after all workers finished", func(t *testing.T) {
+		s := settings.New()
+		s.Concurrency = 4
+
+		mdb := newMockDb(database.New(s))
+		mdb.tables = newTables(8)
+		mdb.tables[3].Name = "invalid$table"
+
+		mdb.
+			On("GetTables").
+			Return(mdb.tables, nil)
+		mdb.
+			On("PrepareGetColumnsOfTableStmt").
+			Return(nil)
+
+		w := newMockWriter()
+		for i, table := range mdb.tables {
+			mdb.On("GetColumnsOfTable", table)
+			w.On(
+				"Write",
+				fmt.Sprintf("TestTable%d", i+1),
+				fmt.Sprintf("package dto\n\ntype TestTable%d struct {\nColumnName int `db:\"column_name\"`\n}", i+1),
+			).Maybe()
+		}
+
+		err := Run(s, mdb, w)
+		assert.EqualError(t, err, `could not create string for table "invalid$table": table name "invalid$table" contains invalid characters`)
+	})
+}
+
+func TestValidVariableName(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    string
+		expected bool
+	}
+	tests := []testCase{
+		{"basic", "MyVariable_2", true},
+		{"specialChars", "MyVar;iable", false},
+		{"brackets", "MyVariabl(e)", false},
+		{"nonEnglish", "MyVαriαble", true},
+		{"spaces", "My Variable", false},
+		{"whitespace", "My		Variable", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if validVariableName(tc.input) != tc.expected {
+				t.Errorf("TestValidVariableName(%q) should be %t", tc.input, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReplaceSpace(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    rune
+		expected rune
+	}
+	tests := []testCase{
+		{"letter", 'a', 'a'},
+		{"number", '7', '7'},
+		{"nonEnglish", '水', '水'},
+		{"space", ' ', '_'},
+		{"underscore", '_', '_'},
+		{"tab", '\t', '_'},
+		{"newline", '\n', '_'},
+		{"zeroWidthSpace", '​', '_'},
+		{"nonBreakingSpace", ' ', '_'},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output := replaceSpace(tc.input)
+			if output != tc.expected {
+				t.Errorf("replaceSpace(%q) = %q, expected %q", tc.input, output, tc.expected)
+			}
+		})
+	}
+
+}
+
+func TestSanitizeKeyword(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected string
+	}{
+		{
+			desc:     "keyword type gets an underscore appended",
+			input:    "type",
+			expected: "type_",
+		},
+		{
+			desc:     "keyword func gets an underscore appended",
+			input:    "func",
+			expected: "func_",
+		},
+		{
+			desc:     "keyword interface gets an underscore appended",
+			input:    "interface",
+			expected: "interface_",
+		},
+		{
+			desc:     "exported name of a keyword is kept",
+			input:    "Select",
+			expected: "Select",
+		},
+		{
+			desc:     "other names are kept",
+			input:    "users",
+			expected: "users",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual := sanitizeKeyword(tt.input)
+			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
+		})
+	}
+}
+
+func TestRun_KeywordNames(t *testing.T) {
+	for _, keyword := range []string{"type", "func", "interface", "select"} {
+		t.Run(keyword, func(t *testing.T) {
+			s := settings.New()
+
+			table := &database.Table{
+				Name: keyword,
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            keyword,
+						DataType:        "integer",
+					},
+				},
+			}
+
+			name := titleCase(keyword)
+			assertRunWritesTable(t, s, table, name,
+				"package dto\n\ntype "+name+" struct {\n"+name+" int `db:\""+keyword+"\"`\n}")
+		})
+	}
+}
+
+func TestFormatColumnName(t *testing.T) {
+	t.Run("pass", func(t *testing.T) {
+		type testCase struct {
+			name     string
+			input    string
 			original string
 			camel    string
 		}
@@ -1989,6 +5521,12 @@ func TestFormatColumnName(t *testing.T) {
 			{"snakeCase", "my_column", "My_column", "MyColumn"},
 			{"titleSnake", "My_Column", "My_Column", "MyColumn"},
 			{"numbersOnly", "123", "X_123", "X123"},
+			{"startWithNumberSnakeCase", "1st_place", "X_1st_place", "X1stPlace"},
+			{"startWithNumberInitialism", "2fa_enabled", "X_2fa_enabled", "X2faEnabled"},
+			{"startWithNumberLetter", "3d_model", "X_3d_model", "X3dModel"},
+			{"semicolons", "MyColumn;", "MyColumn", "MyColumn"},
+			{"brackets", "MyColumn()", "MyColumn", "MyColumn"},
+			{"hyphens", "my-column", "MyColumn", "MyColumn"},
 			{"nonEnglish", "火", "火", "火"},
 			{"nonEnglishUpper", "Λλ", "Λλ", "Λλ"},
 		}
@@ -1998,7 +5536,7 @@ func TestFormatColumnName(t *testing.T) {
 		t.Run("camelcase", func(t *testing.T) {
 			for _, tc := range tests {
 				t.Run(tc.name, func(t *testing.T) {
-					output, err := formatColumnName(camelSettings, tc.input, "MyTable")
+					output, err := formatColumnName(camelSettings, quietLog, tc.input, "MyTable")
 					if err != nil {
 						t.Error(err)
 					} else if output != tc.camel {
@@ -2013,7 +5551,7 @@ func TestFormatColumnName(t *testing.T) {
 		t.Run("original", func(t *testing.T) {
 			for _, tc := range tests {
 				t.Run(tc.name, func(t *testing.T) {
-					output, err := formatColumnName(originalSettings, tc.input, "MyTable")
+					output, err := formatColumnName(originalSettings, quietLog, tc.input, "MyTable")
 					if err != nil {
 						t.Error(err)
 					} else if output != tc.original {
@@ -2030,13 +5568,13 @@ func TestFormatColumnName(t *testing.T) {
 			input string
 		}
 		tests := []testCase{
-			{"semicolons", "MyColumn;"},
-			{"brackets", "MyColumn()"},
+			{"invalidOnly", ";()"},
+			{"emptyName", ""},
 		}
 		s := settings.New()
 		for _, tc := range tests {
 			t.Run(tc.name, func(t *testing.T) {
-				_, err := formatColumnName(s, tc.input, "MyTable")
+				_, err := formatColumnName(s, quietLog, tc.input, "MyTable")
 				if err == nil {
 					t.Errorf("formatColumnName(%q) should have thrown error but didn't", tc.input)
 				}
@@ -2044,3 +5582,816 @@ func TestFormatColumnName(t *testing.T) {
 		}
 	})
 }
+
+func TestRun_Unexported(t *testing.T) {
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "user_id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "type",
+				DataType:        "character varying",
+				IsNullable:      "NO",
+				DefaultValue:    sql.NullString{String: "'guest'::character varying", Valid: true},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc: "struct and field names get unexported, keywords sanitized",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Unexported = true
+				return s
+			},
+			expected: "package dto\n\n" +
+				"type users struct {\nuserID int `db:\"user_id\"`\ntype_ string `db:\"type\"`\n}",
+		},
+		{
+			desc: "derived names of constructor, getters and column map stay exported",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Unexported = true
+				s.Constructor = true
+				s.ColumnMap = true
+				s.ScanHelper = true
+				s.Getters = true
+				return s
+			},
+			expected: "package dto\n\n" +
+				"type users struct {\nuserID int `db:\"user_id\"`\ntype_ string `db:\"type\"`\n}" +
+				"\n\nfunc newUsers() *users {\nreturn &users{\ntype_: \"guest\",\n}\n}" +
+				"\n\nvar UsersColumns = struct {\nUserID string\nType string\n}{\nUserID: \"user_id\",\nType: \"type\",\n}" +
+				"\n\nfunc (u *users) ScanDest() []interface{} {\nreturn []interface{}{\n&u.userID,\n&u.type_,\n}\n}" +
+				"\n\nfunc (u users) GetUserID() int {\nreturn u.userID\n}" +
+				"\n\nfunc (u users) GetType() string {\nreturn u.type_\n}" +
+				"\n\ntype UsersGetter interface {\nGetUserID() int\nGetType() string\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assertRunWritesTable(t, test.settings(), table, "Users", test.expected)
+		})
+	}
+}
+
+func TestUnexportedName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Users", expected: "users"},
+		{input: "ID", expected: "id"},
+		{input: "UserID", expected: "userID"},
+		{input: "HTTPHost", expected: "httpHost"},
+		{input: "Type", expected: "type_"},
+		{input: "X_1st", expected: "x_1st"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, unexportedName(test.input))
+		})
+	}
+}
+
+func TestRun_IntervalColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc:     "default maps interval columns to annotated strings",
+			settings: settings.New,
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\n" +
+				"type TestTable struct {\nCreatedAt time.Time `db:\"created_at\"`\nDuration string `db:\"duration\"` // interval\nTimeout sql.NullString `db:\"timeout\"` // interval\n}",
+		},
+		{
+			desc: "interval-type maps interval columns to the given type, imported once",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.IntervalType = "time.Duration"
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\n" +
+				"type TestTable struct {\nCreatedAt time.Time `db:\"created_at\"`\nDuration time.Duration `db:\"duration\"`\nTimeout *time.Duration `db:\"timeout\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "created_at",
+						DataType:        "timestamp without time zone",
+						IsNullable:      "NO",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "duration",
+						DataType:        "interval",
+						IsNullable:      "NO",
+					},
+					{
+						OrdinalPosition: 3,
+						Name:            "timeout",
+						DataType:        "interval",
+						IsNullable:      "YES",
+					},
+				},
+			}
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
+
+func TestRun_MySQLYearColumns(t *testing.T) {
+	s := settings.New()
+	s.DbType = settings.DBTypeMySQL
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "founded",
+				DataType:        "year",
+				ColumnType:      "year",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "closed",
+				DataType:        "year",
+				ColumnType:      "year",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	assertRunWritesTable(t, s, table, "TestTable",
+		"package dto\n\nimport (\n\t\"database/sql\"\n)\n\n"+
+			"type TestTable struct {\nFounded int `db:\"founded\"`\nClosed sql.NullInt64 `db:\"closed\"`\n}")
+}
+
+func TestRun_MySQLTextBlobColumns(t *testing.T) {
+	for _, columnType := range []string{"tinytext", "text", "mediumtext", "longtext"} {
+		t.Run(columnType, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = settings.DBTypeMySQL
+			s.BlobBytes = true
+
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{OrdinalPosition: 1, Name: "body", DataType: columnType, IsNullable: "NO"},
+					{OrdinalPosition: 2, Name: "summary", DataType: columnType, IsNullable: "YES"},
+				},
+			}
+
+			assertRunWritesTable(t, s, table, "TestTable",
+				"package dto\n\nimport (\n\t\"database/sql\"\n)\n\n"+
+					"type TestTable struct {\nBody string `db:\"body\"`\nSummary sql.NullString `db:\"summary\"`\n}")
+		})
+	}
+
+	for _, columnType := range []string{"tinyblob", "blob", "mediumblob", "longblob"} {
+		t.Run(columnType, func(t *testing.T) {
+			table := &database.Table{
+				Name: "test_table",
+				Columns: []database.Column{
+					{OrdinalPosition: 1, Name: "data", DataType: columnType, IsNullable: "NO"},
+					{OrdinalPosition: 2, Name: "thumbnail", DataType: columnType, IsNullable: "YES"},
+				},
+			}
+
+			t.Run("as string by default", func(t *testing.T) {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+
+				assertRunWritesTable(t, s, table, "TestTable",
+					"package dto\n\nimport (\n\t\"database/sql\"\n)\n\n"+
+						"type TestTable struct {\nData string `db:\"data\"`\nThumbnail sql.NullString `db:\"thumbnail\"`\n}")
+			})
+
+			t.Run("as bytes with blob-bytes", func(t *testing.T) {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.BlobBytes = true
+
+				assertRunWritesTable(t, s, table, "TestTable",
+					"package dto\n\ntype TestTable struct {\nData []byte `db:\"data\"`\nThumbnail []byte `db:\"thumbnail\"`\n}")
+			})
+		})
+	}
+}
+
+func TestRun_DomainColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc:     "default maps domain columns by their base type and notes the domain",
+			settings: settings.New,
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\n" +
+				"type Users struct {\nEmail string `db:\"email\"` // domain: email\nBalance sql.NullFloat64 `db:\"balance\"` // domain: money_amount\n}",
+		},
+		{
+			desc: "domain-map maps domain columns to the given types",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DomainMap = settings.Map{"money_amount": "github.com/shopspring/decimal.Decimal"}
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"github.com/shopspring/decimal\"\n)\n\n" +
+				"type Users struct {\nEmail string `db:\"email\"` // domain: email\nBalance *decimal.Decimal `db:\"balance\"`\n}",
+		},
+		{
+			desc: "type override takes precedence over domain-map",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DomainMap = settings.Map{"email": "Email"}
+				s.TypeOverride = settings.Map{"email": "string"}
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\n" +
+				"type Users struct {\nEmail string `db:\"email\"`\nBalance sql.NullFloat64 `db:\"balance\"` // domain: money_amount\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name: "users",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "email",
+						DataType:        "text",
+						UdtName:         "text",
+						DomainName:      sql.NullString{String: "email", Valid: true},
+						IsNullable:      "NO",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "balance",
+						DataType:        "numeric",
+						UdtName:         "numeric",
+						DomainName:      sql.NullString{String: "money_amount", Valid: true},
+						IsNullable:      "YES",
+					},
+				},
+			}
+			assertRunWritesTable(t, test.settings(), table, "Users", test.expected)
+		})
+	}
+}
+
+func TestRun_IDType(t *testing.T) {
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				IsPrimaryKey:    true,
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "code",
+				DataType:        "character varying",
+				IsNullable:      "NO",
+				IsPrimaryKey:    true,
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "parent_id",
+				DataType:        "integer",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc: "integer primary key columns get the id type",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.IDType = "ID"
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\n" +
+				"type Users struct {\nID ID `db:\"id\"`\nCode string `db:\"code\"`\nParentID sql.NullInt64 `db:\"parent_id\"`\n}",
+		},
+		{
+			desc: "qualified id type gets imported",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.IDType = "github.com/example/graph.ID"
+				return s
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n\t\"github.com/example/graph\"\n)\n\n" +
+				"type Users struct {\nID graph.ID `db:\"id\"`\nCode string `db:\"code\"`\nParentID sql.NullInt64 `db:\"parent_id\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assertRunWritesTable(t, test.settings(), table, "Users", test.expected)
+		})
+	}
+}
+
+func TestRun_Since(t *testing.T) {
+	s := settings.New()
+	s.OutputFilePath = t.TempDir()
+	// all changes were made before, only the tables changed by now get written
+	s.Since = settings.Timestamp{Time: time.Now().Add(time.Hour)}
+	s.FieldMeta = true
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+		},
+	}
+
+	fileName := filepath.Join(s.OutputFilePath, "Users"+output.FileWriterExtension)
+	fieldMetaFileName := filepath.Join(s.OutputFilePath, "FieldMeta"+output.FileWriterExtension)
+
+	run := func() {
+		t.Helper()
+
+		mdb := newMockDb(database.New(s))
+		mdb.tables = append(mdb.tables, table)
+		mdb.
+			On("GetTables").
+			Return(mdb.tables, nil)
+		mdb.
+			On("PrepareGetColumnsOfTableStmt").
+			Return(nil)
+		mdb.
+			On("GetColumnsOfTable", table)
+
+		w := output.NewFileWriter(s.OutputFilePath)
+		w.SetDecorators(output.HeaderDecorator{Header: s.Header})
+		if err := Run(s, mdb, w); err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+	}
+
+	// marks the files to tell if they got written again
+	mark := func() {
+		t.Helper()
+		for _, name := range []string{fileName, fieldMetaFileName} {
+			if err := os.WriteFile(name, []byte("marked"), 0666); err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+		}
+	}
+
+	content := func(name string) string {
+		t.Helper()
+		actual, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+		return string(actual)
+	}
+
+	run()
+	assert.FileExists(t, filepath.Join(s.OutputFilePath, snapshotFileName))
+	assert.Contains(t, content(fileName), "type Users struct")
+	assert.Contains(t, content(fieldMetaFileName), "type FieldMeta struct")
+
+	mark()
+	run()
+	assert.Equal(t, "marked", content(fileName), "unchanged table gets skipped")
+	assert.Equal(t, "marked", content(fieldMetaFileName), "unchanged file gets skipped")
+
+	table.Columns = append(table.Columns, database.Column{
+		OrdinalPosition: 2,
+		Name:            "name",
+		DataType:        "text",
+	})
+	run()
+	assert.Contains(t, content(fileName), "Name string", "changed table gets written")
+	assert.Equal(t, "marked", content(fieldMetaFileName), "unchanged file gets skipped")
+
+	mark()
+	s.TagsJSON = true
+	run()
+	assert.Contains(t, content(fileName), "json:\"name\"", "changed settings write the table")
+	assert.Contains(t, content(fieldMetaFileName), "type FieldMeta struct", "changed settings write the file")
+
+	mark()
+	s.Header = "Code generated by tables-to-go. DO NOT EDIT."
+	run()
+	assert.Contains(t, content(fileName), "// Code generated by tables-to-go. DO NOT EDIT.", "changed header writes the table")
+
+	mark()
+	s.NoFormat = true
+	run()
+	assert.NotEqual(t, "marked", content(fileName), "changed formatting writes the table")
+
+	if err := os.Remove(fileName); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	run()
+	assert.Contains(t, content(fileName), "type Users struct", "missing file gets written")
+
+	mark()
+	s.Since = settings.Timestamp{Time: time.Now().Add(-time.Hour)}
+	run()
+	assert.Contains(t, content(fileName), "type Users struct", "table changed since then gets written")
+}
+
+func TestRun_Ent(t *testing.T) {
+	header := "package schema\n\nimport (\n\t\"entgo.io/ent\"\n\t\"entgo.io/ent/dialect/entsql\"\n\t\"entgo.io/ent/schema\"\n\t\"entgo.io/ent/schema/field\"\n)\n\n" +
+		"// Users holds the schema definition of the table \"users\".\ntype Users struct {\nent.Schema\n}\n\n" +
+		"// Annotations of the Users.\nfunc (Users) Annotations() []schema.Annotation {\nreturn []schema.Annotation{\nentsql.Annotation{Table: \"users\"},\n}\n}\n\n" +
+		"// Fields of the Users.\nfunc (Users) Fields() []ent.Field {\nreturn []ent.Field{\n"
+
+	tests := []struct {
+		desc     string
+		columns  []database.Column
+		expected string
+	}{
+		{
+			desc: "columns are mapped to the fields of ent",
+			columns: []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "user_id",
+					DataType:        "integer",
+					IsNullable:      "NO",
+					ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+				},
+				{
+					OrdinalPosition: 2,
+					Name:            "email",
+					DataType:        "character varying",
+					IsNullable:      "NO",
+					ConstraintName:  sql.NullString{String: "users_email_key", Valid: true},
+					ConstraintType:  sql.NullString{String: "UNIQUE", Valid: true},
+				},
+				{
+					OrdinalPosition: 3,
+					Name:            "bio",
+					DataType:        "text",
+					IsNullable:      "YES",
+				},
+				{
+					OrdinalPosition: 4,
+					Name:            "created_at",
+					DataType:        "timestamp without time zone",
+					IsNullable:      "NO",
+				},
+				{
+					OrdinalPosition: 5,
+					Name:            "tags",
+					DataType:        "ARRAY",
+					UdtName:         "_text",
+					IsNullable:      "YES",
+				},
+			},
+			expected: header +
+				"field.Int(\"id\").\nStorageKey(\"user_id\"),\n" +
+				"field.String(\"email\").\nUnique(),\n" +
+				"field.Text(\"bio\").\nOptional().\nNillable(),\n" +
+				"field.Time(\"created_at\"),\n" +
+				"field.String(\"tags\").\nOptional().\nNillable(), // pq.StringArray is not supported by ent\n" +
+				"}\n}",
+		},
+		{
+			desc: "composite keys are noted, the columns of composite unique constraints are not unique",
+			columns: []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "tenant_id",
+					DataType:        "integer",
+					IsNullable:      "NO",
+					ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+				},
+				{
+					OrdinalPosition: 1,
+					Name:            "tenant_id",
+					DataType:        "integer",
+					IsNullable:      "NO",
+					ConstraintName:  sql.NullString{String: "users_tenant_id_name_key", Valid: true},
+					ConstraintType:  sql.NullString{String: "UNIQUE", Valid: true},
+				},
+				{
+					OrdinalPosition: 2,
+					Name:            "id",
+					DataType:        "integer",
+					IsNullable:      "NO",
+					ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+				},
+				{
+					OrdinalPosition: 3,
+					Name:            "name",
+					DataType:        "character varying",
+					IsNullable:      "NO",
+					ConstraintName:  sql.NullString{String: "users_tenant_id_name_key", Valid: true},
+					ConstraintType:  sql.NullString{String: "UNIQUE", Valid: true},
+				},
+			},
+			expected: header +
+				"// the composite primary key (tenant_id, id) is not supported by ent\n" +
+				"field.Int(\"tenant_id\"),\n" +
+				"field.Int(\"id\"),\n" +
+				"field.String(\"name\"),\n" +
+				"}\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Ent = true
+			s.PackageName = "schema"
+
+			table := &database.Table{Name: "users", Columns: test.columns}
+
+			mdb := newMockDb(database.New(s))
+			mdb.tables = append(mdb.tables, table)
+			mdb.
+				On("GetTables").
+				Return(mdb.tables, nil)
+			mdb.
+				On("PrepareGetColumnsOfTableStmt").
+				Return(nil)
+			mdb.
+				On("GetColumnsOfTable", table)
+
+			w := newMockWriter()
+			w.On("Write", "Users", test.expected)
+
+			err := Run(s, mdb, w)
+			assert.NoError(t, err)
+			w.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_FieldMeta(t *testing.T) {
+	s := settings.New()
+	s.FieldMeta = true
+	s.Embed = settings.List{"created_at"}
+
+	users := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "created_at",
+				DataType:        "timestamp",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "name",
+				DataType:        "text",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	mdb := newMockDb(database.New(s))
+	mdb.tables = []*database.Table{users}
+
+	mdb.On("GetTables")
+	mdb.On("GetColumnsOfTables", mock.Anything)
+
+	w := newMockWriter()
+	w.On("Write", "Users",
+		"package dto\n\nimport (\n\t\"database/sql\"\n)\n\n"+
+			"type Users struct {\nID int `db:\"id\"`\nAuditFields\nName sql.NullString `db:\"name\"`\n}"+
+			"\n\nfunc (Users) Fields() []FieldMeta {\nreturn []FieldMeta{\n"+
+			"{Name: \"ID\", Column: \"id\", Type: \"int\"},\n"+
+			"{Name: \"CreatedAt\", Column: \"created_at\", Type: \"time.Time\"},\n"+
+			"{Name: \"Name\", Column: \"name\", Type: \"sql.NullString\"},\n}\n}")
+	w.On("Write", "AuditFields",
+		"package dto\n\nimport (\n\t\"time\"\n)\n\n"+
+			"type AuditFields struct {\nCreatedAt time.Time `db:\"created_at\"`\n}")
+	w.On("Write", "FieldMeta",
+		"package dto\n\n// FieldMeta describes a field of a struct along with its column.\n"+
+			"type FieldMeta struct {\nName string\nColumn string\nType string\n}")
+
+	err := Run(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_Registry(t *testing.T) {
+	tests := []struct {
+		desc     string
+		schema   string
+		expected string
+	}{
+		{
+			desc:     "names of the tables of a single schema",
+			schema:   "public",
+			expected: "package dto\n\n// AllTables holds the names of all tables.\nvar AllTables = []string{\n\"users\",\n\"orders\",\n}",
+		},
+		{
+			desc:     "names of the tables of multiple schemas are qualified",
+			schema:   "public,audit",
+			expected: "package dto\n\n// AllTables holds the names of all tables.\nvar AllTables = []string{\n\"public.users\",\n\"audit.orders\",\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Schema = test.schema
+			s.Registry = true
+
+			columns := []database.Column{
+				{
+					OrdinalPosition: 1,
+					Name:            "id",
+					DataType:        "integer",
+					IsNullable:      "NO",
+				},
+			}
+
+			mdb := newMockDb(database.New(s))
+			mdb.tables = []*database.Table{
+				{Name: "users", Schema: "public", Columns: columns},
+				{Name: "orders", Schema: "audit", Columns: columns},
+			}
+
+			mdb.On("GetTables")
+			mdb.On("PrepareGetColumnsOfTableStmt")
+			mdb.On("GetColumnsOfTable", mock.Anything)
+
+			w := newMockWriter()
+			w.On("Write", mock.Anything, mock.Anything).Maybe()
+			w.On("Write", "AllTables", test.expected).Once()
+
+			err := Run(s, mdb, w)
+			assert.NoError(t, err)
+			w.AssertCalled(t, "Write", "AllTables", test.expected)
+		})
+	}
+}
+
+func TestRun_RegistryOfFailedTables(t *testing.T) {
+	s := settings.New()
+	s.Registry = true
+	s.Force = true
+
+	columns := []database.Column{
+		{
+			OrdinalPosition: 1,
+			Name:            "id",
+			DataType:        "integer",
+			IsNullable:      "NO",
+		},
+	}
+
+	mdb := newMockDb(database.New(s))
+	mdb.tables = []*database.Table{
+		{Name: "users", Schema: "public", Columns: columns},
+		{Name: "!!!", Schema: "public", Columns: columns},
+	}
+
+	mdb.On("GetTables")
+	mdb.On("PrepareGetColumnsOfTableStmt")
+	mdb.On("GetColumnsOfTable", mock.Anything)
+
+	// the failed table has no struct to be listed
+	w := newMockWriter()
+	w.On("Write", "Users", "package dto\n\ntype Users struct {\nID int `db:\"id\"`\n}")
+	w.On("Write", "AllTables", "package dto\n\n// AllTables holds the names of all tables.\nvar AllTables = []string{\n\"users\",\n}")
+
+	err := Run(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_PackageMap(t *testing.T) {
+	s := settings.New()
+	s.PackageMap = settings.Map{"users": "auth", "public.orders": "commerce"}
+	s.Registry = true
+
+	columns := []database.Column{
+		{
+			OrdinalPosition: 1,
+			Name:            "id",
+			DataType:        "integer",
+			IsNullable:      "NO",
+		},
+	}
+
+	mdb := newMockDb(database.New(s))
+	mdb.tables = []*database.Table{
+		{Name: "users", Schema: "public", Columns: columns},
+		{Name: "orders", Schema: "public", Columns: columns},
+		{Name: "logs", Schema: "public", Columns: columns},
+	}
+
+	mdb.On("GetTables")
+	mdb.On("PrepareGetColumnsOfTableStmt")
+	mdb.On("GetColumnsOfTable", mock.Anything)
+
+	w := newMockWriter()
+	w.On("Write", "auth/Users", "package auth\n\ntype Users struct {\nID int `db:\"id\"`\n}")
+	w.On("Write", "commerce/Orders", "package commerce\n\ntype Orders struct {\nID int `db:\"id\"`\n}")
+	w.On("Write", "Logs", "package dto\n\ntype Logs struct {\nID int `db:\"id\"`\n}")
+	w.On("Write", "auth/AllTables", "package auth\n\n// AllTables holds the names of all tables.\nvar AllTables = []string{\n\"users\",\n}")
+	w.On("Write", "commerce/AllTables", "package commerce\n\n// AllTables holds the names of all tables.\nvar AllTables = []string{\n\"orders\",\n}")
+	w.On("Write", "AllTables", "package dto\n\n// AllTables holds the names of all tables.\nvar AllTables = []string{\n\"logs\",\n}")
+
+	err := Run(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_ImportBlock(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		columns  []database.Column
+		expected string
+	}{
+		{
+			desc: "structable recorder gets imported like any other package",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.IsMastermindStructableRecorder = true
+				return s
+			},
+			columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+			},
+			expected: "package dto\n\nimport (\n\t\"github.com/Masterminds/structable\"\n)\n\n" +
+				"type TestTable struct {\nID int `db:\"id\"`\n\nstructable.Recorder\n}",
+		},
+		{
+			desc: "time of temporal columns gets imported once",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.IntervalType = "time.Duration"
+				return s
+			},
+			columns: []database.Column{
+				{OrdinalPosition: 1, Name: "created_at", DataType: "timestamp", IsNullable: "NO"},
+				{OrdinalPosition: 2, Name: "timeout", DataType: "interval", IsNullable: "NO"},
+			},
+			expected: "package dto\n\nimport (\n\t\"time\"\n)\n\n" +
+				"type TestTable struct {\nCreatedAt time.Time `db:\"created_at\"`\nTimeout time.Duration `db:\"timeout\"`\n}",
+		},
+		{
+			desc: "no import block without imports",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Null = settings.NullTypePrimitive
+				return s
+			},
+			columns: []database.Column{
+				{OrdinalPosition: 1, Name: "name", DataType: "text", IsNullable: "YES"},
+			},
+			expected: "package dto\n\ntype TestTable struct {\nName *string `db:\"name\"`\n}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			table := &database.Table{
+				Name:    "test_table",
+				Columns: test.columns,
+			}
+
+			assertRunWritesTable(t, test.settings(), table, "TestTable", test.expected)
+		})
+	}
+}
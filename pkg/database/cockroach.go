@@ -0,0 +1,88 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// Cockroach implements the Database interface for CockroachDB. It speaks the
+// Postgresql wire protocol and provides the same information_schema, so it
+// reuses the queries of Postgresql. Only the datatypes differ: CockroachDB
+// reports its own aliases, eg. `string` or `int8`, partly in upper case.
+type Cockroach struct {
+	*Postgresql
+}
+
+// NewCockroach creates a new Cockroach database.
+func NewCockroach(s *settings.Settings) *Cockroach {
+	pg := NewPostgresql(s)
+	pg.defaultUserName = "root"
+	return &Cockroach{
+		Postgresql: pg,
+	}
+}
+
+// GetStringDatatypes returns the string datatypes for the Cockroach database.
+func (c *Cockroach) GetStringDatatypes() []string {
+	return append(c.Postgresql.GetStringDatatypes(),
+		"string",
+	)
+}
+
+// IsString returns true if colum is of type string for the Cockroach database.
+func (c *Cockroach) IsString(column Column) bool {
+	return isStringInSlice(strings.ToLower(column.DataType), c.GetStringDatatypes())
+}
+
+// IsText returns true if colum is of type text for the Cockroach database.
+func (c *Cockroach) IsText(column Column) bool {
+	return isStringInSlice(strings.ToLower(column.DataType), c.GetTextDatatypes())
+}
+
+// GetIntegerDatatypes returns the integer datatypes for the Cockroach database.
+func (c *Cockroach) GetIntegerDatatypes() []string {
+	return append(c.Postgresql.GetIntegerDatatypes(),
+		"int",
+		"int2",
+		"int4",
+		"int8",
+	)
+}
+
+// IsInteger returns true if colum is of type integer for the Cockroach database.
+func (c *Cockroach) IsInteger(column Column) bool {
+	return isStringInSlice(strings.ToLower(column.DataType), c.GetIntegerDatatypes())
+}
+
+// GetFloatDatatypes returns the float datatypes for the Cockroach database.
+func (c *Cockroach) GetFloatDatatypes() []string {
+	return append(c.Postgresql.GetFloatDatatypes(),
+		"float",
+		"float4",
+		"float8",
+	)
+}
+
+// IsFloat returns true if colum is of type float for the Cockroach database.
+func (c *Cockroach) IsFloat(column Column) bool {
+	return isStringInSlice(strings.ToLower(column.DataType), c.GetFloatDatatypes())
+}
+
+// GetTemporalDatatypes returns the temporal datatypes for the Cockroach database.
+func (c *Cockroach) GetTemporalDatatypes() []string {
+	return append(c.Postgresql.GetTemporalDatatypes(),
+		"timetz",
+		"timestamptz",
+	)
+}
+
+// IsTemporal returns true if colum is of type temporal for the Cockroach database.
+func (c *Cockroach) IsTemporal(column Column) bool {
+	return isStringInSlice(strings.ToLower(column.DataType), c.GetTemporalDatatypes())
+}
+
+// IsJSON returns true if colum is of type JSON for the Cockroach database.
+func (c *Cockroach) IsJSON(column Column) bool {
+	return isStringInSlice(strings.ToLower(column.DataType), c.GetJSONDatatypes())
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestCockroach_DSN(t *testing.T) {
+	s := settings.New()
+	s.DbType = settings.DBTypeCockroach
+	s.Port = "26257"
+
+	db := NewCockroach(s)
+
+	expected := fmt.Sprintf("host=%s port=26257 user=root dbname=%s password=%s sslmode=disable",
+		s.Host, s.DbName, s.Pswd)
+	assert.Equal(t, expected, db.DSN())
+}
+
+func TestCockroach_Datatypes(t *testing.T) {
+	db := NewCockroach(settings.New())
+
+	tests := []struct {
+		dataType string
+		is       func(Column) bool
+	}{
+		{dataType: "STRING", is: db.IsString},
+		{dataType: "string", is: db.IsString},
+		{dataType: "character varying", is: db.IsString},
+		{dataType: "INT8", is: db.IsInteger},
+		{dataType: "int4", is: db.IsInteger},
+		{dataType: "bigint", is: db.IsInteger},
+		{dataType: "FLOAT8", is: db.IsFloat},
+		{dataType: "TIMESTAMPTZ", is: db.IsTemporal},
+		{dataType: "JSONB", is: db.IsJSON},
+	}
+	for _, test := range tests {
+		t.Run(test.dataType, func(t *testing.T) {
+			assert.True(t, test.is(Column{DataType: test.dataType}))
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
+	"github.com/fraenky8/tables-to-go/pkg/logger"
 	"github.com/fraenky8/tables-to-go/pkg/settings"
 )
 
@@ -15,7 +19,13 @@ var (
 		settings.DBTypePostgresql: "postgres",
 		settings.DBTypeMySQL:      "mysql",
 		settings.DBTypeSQLite:     "sqlite3",
+		settings.DBTypeCockroach:  "postgres",
+		settings.DBTypeRedshift:   "postgres",
 	}
+
+	// connectBackoff is the initial delay between two connection attempts,
+	// it doubles with each retry.
+	connectBackoff = 250 * time.Millisecond
 )
 
 // Database interface for the concrete databases.
@@ -24,13 +34,21 @@ type Database interface {
 	Connect() (err error)
 	Close() (err error)
 
-	GetTables() (tables []*Table, err error)
-	PrepareGetColumnsOfTableStmt() (err error)
-	GetColumnsOfTable(table *Table) (err error)
+	GetTables(ctx context.Context) (tables []*Table, err error)
+	PrepareGetColumnsOfTableStmt(ctx context.Context) (err error)
+	// GetColumnsOfTable sets the columns of the table ordered by their
+	// ordinal position, which may have gaps.
+	GetColumnsOfTable(ctx context.Context, table *Table) (err error)
+	// GetColumnsOfTables sets the columns of all given tables at once, in a
+	// single query if supported by the database.
+	GetColumnsOfTables(ctx context.Context, tables []*Table) (err error)
+	GetForeignKeysOfTable(ctx context.Context, table *Table) (err error)
+	GetRoutines(ctx context.Context) (routines []*Table, err error)
 
 	IsPrimaryKey(column Column) bool
 	IsAutoIncrement(column Column) bool
 	IsNullable(column Column) bool
+	IsUnsigned(column Column) bool
 
 	GetStringDatatypes() []string
 	IsString(column Column) bool
@@ -47,17 +65,43 @@ type Database interface {
 	GetTemporalDatatypes() []string
 	IsTemporal(column Column) bool
 
-	// TODO pg: bitstrings, enum, range, other special types
-	// TODO mysql: bit, enums, set
+	GetBitDatatypes() []string
+	IsBit(column Column) bool
+
+	GetJSONDatatypes() []string
+	IsJSON(column Column) bool
+
+	GetArrayDatatypes() []string
+	IsArray(column Column) bool
+
+	GetEnumDatatypes() []string
+	IsEnum(column Column) bool
+
+	// TODO pg: enum, range, other special types
 }
 
-// Table has a name and a set (slice) of columns.
+// Table has a name, the schema it belongs to, a set (slice) of columns and the
+// foreign keys of the columns. A routine is a table of the result columns of a
+// stored function, its columns are known already.
 type Table struct {
-	Name    string `db:"table_name"`
-	Columns []Column
+	Name        string `db:"table_name"`
+	Schema      string `db:"table_schema"`
+	Columns     []Column
+	ForeignKeys []ForeignKey
+	Routine     bool `db:"-"`
 }
 
-// Column stores information about a column.
+// ForeignKey stores the column referenced by a column of a table.
+type ForeignKey struct {
+	ColumnName       string `db:"column_name"`
+	ReferencedTable  string `db:"referenced_table_name"`
+	ReferencedColumn string `db:"referenced_column_name"`
+}
+
+// Column stores information about a column. The ordinal positions of the
+// columns of a table are ascending but not necessarily contiguous, eg. after
+// dropping a column. They are meant for ordering only, the columns are never
+// looked up by their ordinal position.
 type Column struct {
 	OrdinalPosition        int            `db:"ordinal_position"`
 	Name                   string         `db:"column_name"`
@@ -66,10 +110,64 @@ type Column struct {
 	IsNullable             string         `db:"is_nullable"`
 	CharacterMaximumLength sql.NullInt64  `db:"character_maximum_length"`
 	NumericPrecision       sql.NullInt64  `db:"numeric_precision"`
+	ColumnType             string         `db:"column_type"`     // mysql specific
 	ColumnKey              string         `db:"column_key"`      // mysql specific
 	Extra                  string         `db:"extra"`           // mysql specific
 	ConstraintName         sql.NullString `db:"constraint_name"` // pg specific
 	ConstraintType         sql.NullString `db:"constraint_type"` // pg specific
+	UdtName                string         `db:"udt_name"`        // pg specific
+	DomainName             sql.NullString `db:"domain_name"`     // pg specific
+	Comment                sql.NullString `db:"column_comment"`
+	IsPrimaryKey           bool           `db:"is_primary_key"` // part of a possibly composite primary key
+
+	DistKey      bool `db:"is_dist_key"`    // redshift specific
+	SortKeyOrder int  `db:"sort_key_order"` // redshift specific, negative for interleaved sort keys
+
+	// GoType is the type of the field of the column, set by the generator
+	// before the tags get generated.
+	GoType string `db:"-"`
+}
+
+// BitLength returns the number of bits of a bit column. Postgresql stores
+// it as character_maximum_length, MySQL as numeric_precision. Zero means the
+// length is unknown, eg. for bit varying columns without a length.
+func (c Column) BitLength() int64 {
+	if c.CharacterMaximumLength.Valid {
+		return c.CharacterMaximumLength.Int64
+	}
+	return c.NumericPrecision.Int64
+}
+
+// EnumValues returns the allowed values of an enum column as given by its
+// column type, eg. `enum('a','b')`.
+func (c Column) EnumValues() []string {
+	values := strings.TrimSuffix(strings.TrimPrefix(c.ColumnType, "enum("), ")")
+
+	var (
+		enumValues []string
+		value      strings.Builder
+		quoted     bool
+	)
+
+	for i := 0; i < len(values); i++ {
+		ch := values[i]
+		switch {
+		case ch == '\'' && quoted && i+1 < len(values) && values[i+1] == '\'':
+			// quotes are escaped by doubling them
+			value.WriteByte(ch)
+			i++
+		case ch == '\'':
+			if quoted {
+				enumValues = append(enumValues, value.String())
+				value.Reset()
+			}
+			quoted = !quoted
+		case quoted:
+			value.WriteByte(ch)
+		}
+	}
+
+	return enumValues
 }
 
 // GeneralDatabase represents a base "class" database - for all other concrete
@@ -81,7 +179,14 @@ type GeneralDatabase struct {
 	driver string
 }
 
-// New creates a new Database based on the given type in the settings.
+// log creates the logger respecting quiet and verbose of the settings. The
+// progress goes to stderr if the structs are written to stdout.
+func (gdb *GeneralDatabase) log() *logger.Logger {
+	return logger.New(gdb.Quiet, gdb.Verbose, gdb.OutputStdout)
+}
+
+// New creates a new Database based on the given type in the settings, which
+// reads the schema file instead of the database if given by the settings.
 func New(s *settings.Settings) Database {
 
 	var db Database
@@ -91,31 +196,74 @@ func New(s *settings.Settings) Database {
 		db = NewSQLite(s)
 	case settings.DBTypeMySQL:
 		db = NewMySQL(s)
+	case settings.DBTypeCockroach:
+		db = NewCockroach(s)
+	case settings.DBTypeRedshift:
+		db = NewRedshift(s)
 	case settings.DBTypePostgresql:
 		fallthrough
 	default:
 		db = NewPostgresql(s)
 	}
 
+	// the tables are read from the schema file rather than queried
+	if s.SchemaFile != "" {
+		db = NewFileDatabase(s, db)
+	}
+
 	return db
 }
 
 // Connect establishes a connection to the database with the given DSN.
-// It pings the database to ensure it is reachable.
+// It pings the database to ensure it is reachable. Failed attempts are retried
+// with an increasing backoff as often as given by the settings, as long as the
+// timeout is not elapsed. The error of the last attempt is returned.
 func (gdb *GeneralDatabase) Connect(dsn string) (err error) {
-	gdb.DB, err = sqlx.Connect(gdb.driver, dsn)
-	if err != nil {
-		usingPswd := "no"
-		if gdb.Settings.Pswd != "" {
-			usingPswd = "yes"
+
+	var deadline time.Time
+	if gdb.Timeout > 0 {
+		deadline = time.Now().Add(gdb.Timeout)
+	}
+
+	backoff := connectBackoff
+	for attempt := 0; ; attempt++ {
+		if err = gdb.connect(dsn); err == nil {
+			return nil
+		}
+		if attempt >= gdb.Retries || !deadline.IsZero() && time.Now().Add(backoff).After(deadline) {
+			break
 		}
-		return fmt.Errorf(
-			"could not connect to database (type=%q, user=%q, database=%q, host='%v:%v', using password: %v): %w",
-			gdb.DbType, gdb.User, gdb.DbName, gdb.Host, gdb.Port, usingPswd, err,
-		)
+		gdb.log().Debugf("> Connection attempt %d failed, retrying in %v: %v\r\n", attempt+1, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
 	}
 
-	return gdb.Ping()
+	usingPswd := "no"
+	if gdb.Settings.Pswd != "" {
+		usingPswd = "yes"
+	}
+	return fmt.Errorf(
+		"could not connect to database (type=%q, user=%q, database=%q, host='%v:%v', using password: %v): %w",
+		gdb.DbType, gdb.User, gdb.DbName, gdb.Host, gdb.Port, usingPswd, err,
+	)
+}
+
+// connect makes a single attempt to open and ping the database. The pool of
+// connections is configured before, so that the ping respects it already.
+func (gdb *GeneralDatabase) connect(dsn string) error {
+	db, err := sqlx.Open(gdb.driver, dsn)
+	if err != nil {
+		return err
+	}
+	db.SetMaxOpenConns(gdb.MaxOpenConns)
+	db.SetMaxIdleConns(gdb.MaxIdleConns)
+	db.SetConnMaxLifetime(gdb.ConnMaxLifetime)
+	if err = db.Ping(); err != nil {
+		_ = db.Close()
+		return err
+	}
+	gdb.DB = db
+	return nil
 }
 
 // Close closes the database connection.
@@ -123,11 +271,123 @@ func (gdb *GeneralDatabase) Close() error {
 	return gdb.DB.Close()
 }
 
+// tableTypes returns the information_schema table types to select, views are
+// included only if enabled by the settings.
+func (gdb *GeneralDatabase) tableTypes() string {
+	if gdb.Views {
+		return "'BASE TABLE', 'VIEW'"
+	}
+	return "'BASE TABLE'"
+}
+
+// getTables selects the tables of the given schemas with the given query of
+// the concrete database. The query gets the schemas as list bound to `IN (?)`.
+func (gdb *GeneralDatabase) getTables(ctx context.Context, query string, schemas []string) (tables []*Table, err error) {
+
+	query, args, err := sqlx.In(query, schemas)
+	if err == nil {
+		err = gdb.SelectContext(ctx, &tables, gdb.Rebind(query), args...)
+	}
+
+	if err != nil {
+		gdb.log().Debugf("> Error at GetTables()\r\n")
+		gdb.log().Debugf("> schemas: %q\r\n", schemas)
+	}
+
+	return tables, err
+}
+
+// getColumnsOfTable executes the prepared statement of the concrete database
+// for retrieving the columns of the given table in the given schema.
+func (gdb *GeneralDatabase) getColumnsOfTable(ctx context.Context, table *Table, schema string) (err error) {
+
+	if gdb.GetColumnsOfTableStmt == nil {
+		return fmt.Errorf("statement for retrieving the columns of table %q is not prepared", table.Name)
+	}
+
+	err = gdb.GetColumnsOfTableStmt.SelectContext(ctx, &table.Columns, table.Name, schema)
+
+	if err != nil {
+		gdb.log().Debugf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
+		gdb.log().Debugf("> schema: %q\r\n", schema)
+	}
+
+	return err
+}
+
+// tableColumn is a column along with the table it belongs to.
+type tableColumn struct {
+	TableName   string `db:"table_name"`
+	TableSchema string `db:"table_schema"`
+	Column
+}
+
+// getColumnsOfTables selects the columns of all tables of the given schemas
+// with the given query of the concrete database and sets them to the given
+// tables, the others are dropped. The query gets the schemas as list bound to
+// `IN (?)` and selects the table_name and table_schema of each column.
+func (gdb *GeneralDatabase) getColumnsOfTables(ctx context.Context, query string, tables []*Table, schemas []string, fallback string) (err error) {
+
+	var columns []tableColumn
+
+	query, args, err := sqlx.In(query, schemas)
+	if err == nil {
+		err = gdb.SelectContext(ctx, &columns, gdb.Rebind(query), args...)
+	}
+
+	if err != nil {
+		gdb.log().Debugf("> Error at GetColumnsOfTables()\r\n")
+		gdb.log().Debugf("> schemas: %q\r\n", schemas)
+		return err
+	}
+
+	columnsOfTables := map[string][]Column{}
+	for _, column := range columns {
+		key := column.TableSchema + "." + column.TableName
+		columnsOfTables[key] = append(columnsOfTables[key], column.Column)
+	}
+
+	for _, table := range tables {
+		table.Columns = columnsOfTables[schemaOf(table, fallback)+"."+table.Name]
+	}
+
+	return nil
+}
+
+// getForeignKeysOfTable selects the foreign keys of the given table in the
+// given schema with the given query of the concrete database.
+func (gdb *GeneralDatabase) getForeignKeysOfTable(ctx context.Context, query string, table *Table, schema string) (err error) {
+
+	err = gdb.SelectContext(ctx, &table.ForeignKeys, query, table.Name, schema)
+
+	if err != nil {
+		gdb.log().Debugf("> Error at GetForeignKeysOfTable(%v)\r\n", table.Name)
+		gdb.log().Debugf("> schema: %q\r\n", schema)
+	}
+
+	return err
+}
+
+// schemaOf returns the schema of the table, or the given fallback if the
+// schema of the table is unknown.
+func schemaOf(table *Table, fallback string) string {
+	if table.Schema != "" {
+		return table.Schema
+	}
+	return fallback
+}
+
 // IsNullable returns true if the column is a nullable column.
 func (gdb *GeneralDatabase) IsNullable(column Column) bool {
 	return column.IsNullable == "YES"
 }
 
+// IsUnsigned returns true if the column is an unsigned numeric column. Only
+// MySQL supports the unsigned modifier, it is part of the column type.
+func (gdb *GeneralDatabase) IsUnsigned(column Column) bool {
+	return strings.Contains(column.ColumnType, "unsigned")
+}
+
 // isStringInSlice checks if needle (string) is in haystack ([]string).
 func isStringInSlice(needle string, haystack []string) bool {
 	for _, s := range haystack {
@@ -0,0 +1,317 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		expected Database
+	}{
+		{
+			desc:     "pg database type creates Postgresql database",
+			dbType:   settings.DBTypePostgresql,
+			expected: &Postgresql{},
+		},
+		{
+			desc:     "mysql database type creates MySQL database",
+			dbType:   settings.DBTypeMySQL,
+			expected: &MySQL{},
+		},
+		{
+			desc:     "sqlite3 database type creates SQLite database",
+			dbType:   settings.DBTypeSQLite,
+			expected: &SQLite{},
+		},
+		{
+			desc:     "cockroach database type creates Cockroach database",
+			dbType:   settings.DBTypeCockroach,
+			expected: &Cockroach{},
+		},
+		{
+			desc:     "redshift database type creates Redshift database",
+			dbType:   settings.DBTypeRedshift,
+			expected: &Redshift{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			actual := New(s)
+			assert.IsType(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGeneralDatabase_Connect(t *testing.T) {
+	tests := []struct {
+		desc    string
+		timeout time.Duration
+		retries int
+		maxTime time.Duration
+	}{
+		{
+			desc:    "without retries fails after the first attempt",
+			retries: 0,
+			maxTime: connectBackoff,
+		},
+		{
+			desc:    "retries fail after the backoff",
+			retries: 1,
+			maxTime: 2 * connectBackoff,
+		},
+		{
+			desc:    "elapsed timeout stops the retries",
+			timeout: time.Millisecond,
+			retries: 100,
+			maxTime: connectBackoff,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Timeout = test.timeout
+			s.Retries = test.retries
+			db := &GeneralDatabase{Settings: s, driver: "unknown"}
+
+			start := time.Now()
+			err := db.Connect("")
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), `unknown driver "unknown"`)
+			assert.Less(t, int64(time.Since(start)), int64(test.maxTime))
+		})
+	}
+}
+
+func TestGeneralDatabase_ConnectPool(t *testing.T) {
+	s := settings.New()
+	s.MaxOpenConns = 4
+	db := &GeneralDatabase{Settings: s, driver: "failing"}
+
+	if err := db.Connect(""); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	assert.Equal(t, 4, db.Stats().MaxOpenConnections)
+}
+
+func TestDatabase_GetColumnsOfTable(t *testing.T) {
+	tests := []struct {
+		desc   string
+		dbType settings.DBType
+	}{
+		{
+			desc:   "Postgresql without prepared statement produces error",
+			dbType: settings.DBTypePostgresql,
+		},
+		{
+			desc:   "MySQL without prepared statement produces error",
+			dbType: settings.DBTypeMySQL,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+
+			var db Database = New(s)
+
+			table := &Table{Name: "test_table"}
+			err := db.GetColumnsOfTable(context.Background(), table)
+			assert.Error(t, err)
+			assert.Empty(t, table.Columns)
+		})
+	}
+}
+
+// errFailingQuery is returned by every statement of the failing driver.
+var errFailingQuery = errors.New("relation does not exist")
+
+// failingDriver prepares any statement but fails to execute it, just like a
+// statement does that is valid to prepare but broken at runtime.
+type failingDriver struct{}
+
+func (failingDriver) Open(string) (driver.Conn, error) { return failingConn{}, nil }
+
+type failingConn struct{}
+
+func (failingConn) Prepare(string) (driver.Stmt, error) { return failingStmt{}, nil }
+func (failingConn) Close() error                        { return nil }
+func (failingConn) Begin() (driver.Tx, error)           { return nil, errFailingQuery }
+
+type failingStmt struct{}
+
+func (failingStmt) Close() error                               { return nil }
+func (failingStmt) NumInput() int                              { return -1 }
+func (failingStmt) Exec([]driver.Value) (driver.Result, error) { return nil, errFailingQuery }
+func (failingStmt) Query([]driver.Value) (driver.Rows, error)  { return nil, errFailingQuery }
+
+func init() {
+	sql.Register("failing", failingDriver{})
+}
+
+func TestDatabase_GetColumnsOfTable_FailingStatement(t *testing.T) {
+	tests := []struct {
+		desc   string
+		dbType settings.DBType
+	}{
+		{
+			desc:   "Postgresql surfaces the error of the statement",
+			dbType: settings.DBTypePostgresql,
+		},
+		{
+			desc:   "MySQL surfaces the error of the statement",
+			dbType: settings.DBTypeMySQL,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+
+			var (
+				db  Database
+				gdb *GeneralDatabase
+			)
+			switch test.dbType {
+			case settings.DBTypeMySQL:
+				mysql := NewMySQL(s)
+				db, gdb = mysql, mysql.GeneralDatabase
+			default:
+				pg := NewPostgresql(s)
+				db, gdb = pg, pg.GeneralDatabase
+			}
+
+			sqlDb, err := sql.Open("failing", "")
+			if err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+			gdb.DB = sqlx.NewDb(sqlDb, "failing")
+			t.Cleanup(func() {
+				_ = gdb.Close()
+			})
+
+			if err := db.PrepareGetColumnsOfTableStmt(context.Background()); err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+
+			table := &Table{Name: "test_table"}
+			err = db.GetColumnsOfTable(context.Background(), table)
+			assert.ErrorIs(t, err, errFailingQuery)
+			assert.Empty(t, table.Columns)
+		})
+	}
+}
+
+func TestColumn_BitLength(t *testing.T) {
+	tests := []struct {
+		desc     string
+		column   Column
+		expected int64
+	}{
+		{
+			desc: "Postgresql bit column has character maximum length",
+			column: Column{
+				DataType:               "bit",
+				CharacterMaximumLength: sql.NullInt64{Int64: 1, Valid: true},
+			},
+			expected: 1,
+		},
+		{
+			desc: "MySQL bit column has numeric precision",
+			column: Column{
+				DataType:         "bit",
+				NumericPrecision: sql.NullInt64{Int64: 8, Valid: true},
+			},
+			expected: 8,
+		},
+		{
+			desc: "bit varying column without length has unknown length",
+			column: Column{
+				DataType: "bit varying",
+			},
+			expected: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.column.BitLength())
+		})
+	}
+}
+
+func TestColumn_EnumValues(t *testing.T) {
+	tests := []struct {
+		desc       string
+		columnType string
+		expected   []string
+	}{
+		{
+			desc:       "enum values get parsed",
+			columnType: "enum('active','inactive')",
+			expected:   []string{"active", "inactive"},
+		},
+		{
+			desc:       "enum values with escaped quotes, commas and spaces get parsed",
+			columnType: "enum('it''s','a,b','in progress','')",
+			expected:   []string{"it's", "a,b", "in progress", ""},
+		},
+		{
+			desc:       "column type without enum values returns no values",
+			columnType: "varchar(255)",
+			expected:   nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			column := Column{DataType: "enum", ColumnType: test.columnType}
+			assert.Equal(t, test.expected, column.EnumValues())
+		})
+	}
+}
+
+func TestDatabase_IsUnsigned(t *testing.T) {
+	tests := []struct {
+		desc     string
+		column   Column
+		expected bool
+	}{
+		{
+			desc:     "MySQL unsigned integer column is unsigned",
+			column:   Column{DataType: "int", ColumnType: "int(10) unsigned"},
+			expected: true,
+		},
+		{
+			desc:     "MySQL signed integer column is not unsigned",
+			column:   Column{DataType: "int", ColumnType: "int(11)"},
+			expected: false,
+		},
+		{
+			desc:     "column without column type is not unsigned",
+			column:   Column{DataType: "integer"},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			db := New(settings.New())
+			assert.Equal(t, test.expected, db.IsUnsigned(test.column))
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// SchemaFile is the JSON format of a schema file describing the tables, eg.
+// exported once from a database to generate the structs without connecting
+// to it:
+//
+//	{
+//	  "tables": [
+//	    {
+//	      "schema": "public",
+//	      "name": "users",
+//	      "columns": [
+//	        {"name": "id", "dataType": "integer", "primaryKey": true, "default": "nextval('users_id_seq'::regclass)"},
+//	        {"name": "email", "dataType": "character varying", "characterMaximumLength": 255, "unique": true},
+//	        {"name": "group_id", "dataType": "integer", "nullable": true, "comment": "the group of the user"}
+//	      ],
+//	      "foreignKeys": [
+//	        {"column": "group_id", "referencedTable": "groups", "referencedColumn": "id"}
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// The datatypes are the ones of the database type given by the settings, as
+// given by the information_schema, eg. `character varying` for PostgreSQL.
+type SchemaFile struct {
+	Tables []SchemaFileTable `json:"tables"`
+}
+
+// SchemaFileTable is a table of a schema file. The schema may be omitted if
+// the tables of a single schema are given.
+type SchemaFileTable struct {
+	Schema      string                 `json:"schema,omitempty"`
+	Name        string                 `json:"name"`
+	View        bool                   `json:"view,omitempty"`
+	Columns     []SchemaFileColumn     `json:"columns"`
+	ForeignKeys []SchemaFileForeignKey `json:"foreignKeys,omitempty"`
+}
+
+// SchemaFileColumn is a column of a table of a schema file, given in the order
+// of the columns of the table.
+type SchemaFileColumn struct {
+	Name       string `json:"name"`
+	DataType   string `json:"dataType"`
+	ColumnType string `json:"columnType,omitempty"` // mysql, eg. `tinyint(1) unsigned`
+	UdtName    string `json:"udtName,omitempty"`    // pg, eg. `_int4` of integer arrays
+	Domain     string `json:"domain,omitempty"`     // pg
+	Extra      string `json:"extra,omitempty"`      // mysql, eg. `auto_increment`
+
+	Nullable   bool    `json:"nullable,omitempty"`
+	PrimaryKey bool    `json:"primaryKey,omitempty"`
+	Unique     bool    `json:"unique,omitempty"` // a unique constraint of the column alone
+	Default    *string `json:"default,omitempty"`
+	Comment    string  `json:"comment,omitempty"`
+
+	CharacterMaximumLength *int64 `json:"characterMaximumLength,omitempty"`
+	NumericPrecision       *int64 `json:"numericPrecision,omitempty"`
+}
+
+// SchemaFileForeignKey is a foreign key of a column of a table of a schema
+// file.
+type SchemaFileForeignKey struct {
+	Column           string `json:"column"`
+	ReferencedTable  string `json:"referencedTable"`
+	ReferencedColumn string `json:"referencedColumn,omitempty"`
+}
+
+// FileDatabase reads the tables from a schema file instead of querying a
+// database. The datatypes of the columns are classified by the database of
+// the type given by the settings.
+type FileDatabase struct {
+	Database
+	*settings.Settings
+
+	tables []*Table
+}
+
+// NewFileDatabase creates a database reading the schema file given by the
+// settings, classifying the datatypes by the given database.
+func NewFileDatabase(s *settings.Settings, db Database) *FileDatabase {
+	return &FileDatabase{
+		Database: db,
+		Settings: s,
+	}
+}
+
+// DSN returns the path of the schema file.
+func (f *FileDatabase) DSN() string {
+	return f.SchemaFile
+}
+
+// Connect reads the schema file.
+func (f *FileDatabase) Connect() error {
+
+	file, err := os.Open(f.SchemaFile)
+	if err != nil {
+		return fmt.Errorf("could not open schema file: %w", err)
+	}
+	defer file.Close()
+
+	var schemaFile SchemaFile
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&schemaFile); err != nil {
+		return fmt.Errorf("could not read schema file %q: %w", f.SchemaFile, err)
+	}
+
+	f.tables = nil
+	for i, t := range schemaFile.Tables {
+		if t.Name == "" {
+			return fmt.Errorf("table %d of schema file %q has no name", i+1, f.SchemaFile)
+		}
+		if t.View && !f.Views {
+			continue
+		}
+		if t.Schema != "" && !isStringInSlice(t.Schema, f.Schemas()) {
+			continue
+		}
+		table, err := t.table()
+		if err != nil {
+			return fmt.Errorf("table %q of schema file %q: %w", t.Name, f.SchemaFile, err)
+		}
+		f.tables = append(f.tables, table)
+	}
+
+	return nil
+}
+
+// table converts the table of the schema file.
+func (t SchemaFileTable) table() (*Table, error) {
+
+	table := &Table{
+		Name:   t.Name,
+		Schema: t.Schema,
+	}
+
+	for i, c := range t.Columns {
+		if c.Name == "" || c.DataType == "" {
+			return nil, fmt.Errorf("column %d has no name or datatype", i+1)
+		}
+
+		column := Column{
+			OrdinalPosition: i + 1,
+			Name:            c.Name,
+			DataType:        c.DataType,
+			ColumnType:      c.ColumnType,
+			UdtName:         c.UdtName,
+			Extra:           c.Extra,
+			IsNullable:      "NO",
+			IsPrimaryKey:    c.PrimaryKey,
+			DomainName:      sql.NullString{String: c.Domain, Valid: c.Domain != ""},
+			Comment:         sql.NullString{String: c.Comment, Valid: c.Comment != ""},
+		}
+		if c.Nullable {
+			column.IsNullable = "YES"
+		}
+		if c.Unique {
+			column.ConstraintName = sql.NullString{String: t.Name + "_" + c.Name + "_key", Valid: true}
+			column.ConstraintType = sql.NullString{String: "UNIQUE", Valid: true}
+		}
+		if c.Default != nil {
+			column.DefaultValue = sql.NullString{String: *c.Default, Valid: true}
+		}
+		if c.CharacterMaximumLength != nil {
+			column.CharacterMaximumLength = sql.NullInt64{Int64: *c.CharacterMaximumLength, Valid: true}
+		}
+		if c.NumericPrecision != nil {
+			column.NumericPrecision = sql.NullInt64{Int64: *c.NumericPrecision, Valid: true}
+		}
+
+		table.Columns = append(table.Columns, column)
+	}
+
+	for _, fk := range t.ForeignKeys {
+		table.ForeignKeys = append(table.ForeignKeys, ForeignKey{
+			ColumnName:       fk.Column,
+			ReferencedTable:  fk.ReferencedTable,
+			ReferencedColumn: fk.ReferencedColumn,
+		})
+	}
+
+	return table, nil
+}
+
+// Close does nothing, the schema file is read at once.
+func (f *FileDatabase) Close() error {
+	return nil
+}
+
+// GetTables gets the tables of the schema file, and views if enabled, for the
+// given schemas. Their columns are set by GetColumnsOfTable.
+func (f *FileDatabase) GetTables(_ context.Context) ([]*Table, error) {
+	tables := make([]*Table, 0, len(f.tables))
+	for _, table := range f.tables {
+		tables = append(tables, &Table{
+			Name:   table.Name,
+			Schema: table.Schema,
+		})
+	}
+	return tables, nil
+}
+
+// PrepareGetColumnsOfTableStmt does nothing, the columns are read already.
+func (f *FileDatabase) PrepareGetColumnsOfTableStmt(_ context.Context) error {
+	return nil
+}
+
+// GetColumnsOfTable sets the columns of the table as given by the schema file.
+func (f *FileDatabase) GetColumnsOfTable(_ context.Context, table *Table) error {
+	t, err := f.table(table)
+	if err != nil {
+		return err
+	}
+	table.Columns = append([]Column(nil), t.Columns...)
+	return nil
+}
+
+// GetColumnsOfTables sets the columns of all given tables as given by the
+// schema file.
+func (f *FileDatabase) GetColumnsOfTables(ctx context.Context, tables []*Table) error {
+	for _, table := range tables {
+		if err := f.GetColumnsOfTable(ctx, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetForeignKeysOfTable sets the foreign keys of the table as given by the
+// schema file.
+func (f *FileDatabase) GetForeignKeysOfTable(_ context.Context, table *Table) error {
+	t, err := f.table(table)
+	if err != nil {
+		return err
+	}
+	table.ForeignKeys = append([]ForeignKey(nil), t.ForeignKeys...)
+	return nil
+}
+
+// GetRoutines is not supported by schema files.
+func (f *FileDatabase) GetRoutines(_ context.Context) ([]*Table, error) {
+	return nil, fmt.Errorf("routines are not supported by schema file %q", f.SchemaFile)
+}
+
+// table looks up the given table in the tables of the schema file.
+func (f *FileDatabase) table(table *Table) (*Table, error) {
+	for _, t := range f.tables {
+		if t.Name == table.Name && t.Schema == table.Schema {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("table %q not found in schema file %q", table.Name, f.SchemaFile)
+}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// newFileDatabase writes the schema file and creates a database reading it.
+func newFileDatabase(t *testing.T, s *settings.Settings, content string) *FileDatabase {
+	t.Helper()
+
+	s.SchemaFile = filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(s.SchemaFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	db, ok := New(s).(*FileDatabase)
+	if !ok {
+		t.Fatalf("expected a file database")
+	}
+	return db
+}
+
+func TestFileDatabase(t *testing.T) {
+	s := settings.New()
+	s.Schema = "public,audit"
+
+	db := newFileDatabase(t, s, `{
+		"tables": [
+			{
+				"schema": "public",
+				"name": "users",
+				"columns": [
+					{"name": "id", "dataType": "integer", "primaryKey": true, "default": "nextval('users_id_seq'::regclass)"},
+					{"name": "email", "dataType": "character varying", "characterMaximumLength": 255, "unique": true},
+					{"name": "group_id", "dataType": "integer", "nullable": true, "comment": "the group"}
+				],
+				"foreignKeys": [
+					{"column": "group_id", "referencedTable": "groups", "referencedColumn": "id"}
+				]
+			},
+			{"schema": "public", "name": "active_users", "view": true, "columns": [{"name": "id", "dataType": "integer"}]},
+			{"schema": "other", "name": "logs", "columns": [{"name": "id", "dataType": "integer"}]},
+			{"name": "events", "columns": [{"name": "payload", "dataType": "jsonb"}]}
+		]
+	}`)
+
+	if err := db.Connect(); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	ctx := context.Background()
+
+	tables, err := db.GetTables(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Table{
+		{Name: "users", Schema: "public"},
+		{Name: "events"},
+	}, tables)
+
+	assert.NoError(t, db.PrepareGetColumnsOfTableStmt(ctx))
+	assert.NoError(t, db.GetColumnsOfTable(ctx, tables[0]))
+	assert.NoError(t, db.GetForeignKeysOfTable(ctx, tables[0]))
+
+	assert.Equal(t, []Column{
+		{
+			OrdinalPosition: 1,
+			Name:            "id",
+			DataType:        "integer",
+			IsNullable:      "NO",
+			IsPrimaryKey:    true,
+			DefaultValue:    sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+		},
+		{
+			OrdinalPosition:        2,
+			Name:                   "email",
+			DataType:               "character varying",
+			IsNullable:             "NO",
+			CharacterMaximumLength: sql.NullInt64{Int64: 255, Valid: true},
+			ConstraintName:         sql.NullString{String: "users_email_key", Valid: true},
+			ConstraintType:         sql.NullString{String: "UNIQUE", Valid: true},
+		},
+		{
+			OrdinalPosition: 3,
+			Name:            "group_id",
+			DataType:        "integer",
+			IsNullable:      "YES",
+			Comment:         sql.NullString{String: "the group", Valid: true},
+		},
+	}, tables[0].Columns)
+	assert.Equal(t, []ForeignKey{
+		{ColumnName: "group_id", ReferencedTable: "groups", ReferencedColumn: "id"},
+	}, tables[0].ForeignKeys)
+
+	// the datatypes are classified by the database of the type of the settings
+	assert.True(t, db.IsPrimaryKey(tables[0].Columns[0]))
+	assert.True(t, db.IsAutoIncrement(tables[0].Columns[0]))
+	assert.True(t, db.IsInteger(tables[0].Columns[2]))
+	assert.True(t, db.IsNullable(tables[0].Columns[2]))
+
+	assert.NoError(t, db.GetColumnsOfTables(ctx, tables[1:]))
+	assert.True(t, db.IsJSON(tables[1].Columns[0]))
+
+	err = db.GetColumnsOfTable(ctx, &Table{Name: "unknown"})
+	assert.Error(t, err)
+
+	_, err = db.GetRoutines(ctx)
+	assert.Error(t, err)
+}
+
+func TestFileDatabase_Connect(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		isError assert.ErrorAssertionFunc
+	}{
+		{
+			desc:    "empty schema has no tables",
+			content: `{"tables": []}`,
+			isError: assert.NoError,
+		},
+		{
+			desc:    "invalid JSON produces error",
+			content: `{"tables": [`,
+			isError: assert.Error,
+		},
+		{
+			desc:    "unknown field produces error",
+			content: `{"tables": [{"name": "users", "colums": []}]}`,
+			isError: assert.Error,
+		},
+		{
+			desc:    "table without name produces error",
+			content: `{"tables": [{"columns": []}]}`,
+			isError: assert.Error,
+		},
+		{
+			desc:    "column without datatype produces error",
+			content: `{"tables": [{"name": "users", "columns": [{"name": "id"}]}]}`,
+			isError: assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			db := newFileDatabase(t, settings.New(), test.content)
+			test.isError(t, db.Connect())
+		})
+	}
+
+	t.Run("missing file produces error", func(t *testing.T) {
+		s := settings.New()
+		s.SchemaFile = filepath.Join(t.TempDir(), "missing.json")
+		assert.Error(t, New(s).Connect())
+	})
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -10,6 +11,15 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 )
 
+// mysqlTLSValues maps the SSL modes to the tls parameter of the MySQL driver.
+// The driver cannot verify the certificate chain without the hostname, hence
+// verify-ca falls back to the full verification.
+var mysqlTLSValues = map[settings.SSLMode]string{
+	settings.SSLModeRequire:    "skip-verify",
+	settings.SSLModeVerifyCA:   "true",
+	settings.SSLModeVerifyFull: "true",
+}
+
 // MySQL implements the Database interface with help of GeneralDatabase.
 type MySQL struct {
 	*GeneralDatabase
@@ -34,48 +44,81 @@ func (mysql *MySQL) Connect() error {
 	return mysql.GeneralDatabase.Connect(mysql.DSN())
 }
 
-// DSN creates the DSN String to connect to this database.
+// DSN creates the DSN String to connect to this database, unless given by
+// the settings.
 func (mysql *MySQL) DSN() string {
+	if mysql.Settings.DataSourceName != "" {
+		return mysql.Settings.DataSourceName
+	}
 	user := mysql.defaultUserName
 	if mysql.Settings.User != "" {
 		user = mysql.Settings.User
 	}
 
+	var params []string
+	if mysql.Settings.Timeout > 0 {
+		params = append(params, "timeout="+mysql.Settings.Timeout.String())
+	}
+
+	var dsn string
 	if mysql.Settings.Socket != "" {
-		return fmt.Sprintf("%s:%s@unix(%s)/%s",
-			user, mysql.Settings.Pswd, mysql.Settings.Socket, mysql.Settings.DbName)
+		dsn = fmt.Sprintf("%s:%s@unix(%s)/%s",
+			user, mysql.Settings.Pswd, mysql.Settings.Socket, mysql.database())
+	} else {
+		dsn = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
+			user, mysql.Settings.Pswd, mysql.Settings.Host, mysql.Settings.Port, mysql.database())
+		if tls, ok := mysqlTLSValues[mysql.Settings.SSL]; ok {
+			params = append([]string{"tls=" + tls}, params...)
+		}
 	}
-	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
-		user, mysql.Settings.Pswd, mysql.Settings.Host, mysql.Settings.Port, mysql.Settings.DbName)
+	if len(params) > 0 {
+		dsn += "?" + strings.Join(params, "&")
+	}
+	return dsn
 }
 
-// GetTables gets all tables for a given database by name.
-func (mysql *MySQL) GetTables() (tables []*Table, err error) {
-
-	err = mysql.Select(&tables, `
-		SELECT table_name AS table_name
-		FROM information_schema.tables
-		WHERE table_type = 'BASE TABLE'
-		AND table_schema = ?
-		ORDER BY table_name
-	`, mysql.DbName)
-
-	if mysql.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> schema: %q\r\n", mysql.DbName)
-		}
+// database returns the database to connect to. Multiple databases can be given
+// as comma separated list, the connection is made to the first one.
+func (mysql *MySQL) database() string {
+	if databases := mysql.Schemas(); len(databases) > 0 {
+		return databases[0]
 	}
+	return mysql.Settings.DbName
+}
 
-	return tables, err
+// GetTables gets all tables, and views if enabled, for the given databases by
+// name.
+func (mysql *MySQL) GetTables(ctx context.Context) (tables []*Table, err error) {
+	return mysql.getTables(ctx, `
+		SELECT
+		  table_name AS table_name,
+		  table_schema AS table_schema
+		FROM information_schema.tables
+		WHERE table_type IN (`+mysql.tableTypes()+`)
+		AND table_schema IN (?)
+		ORDER BY table_schema, table_name
+	`, mysql.Schemas())
 }
 
 // PrepareGetColumnsOfTableStmt prepares the statement for retrieving the
 // columns of a specific table for a given database.
-func (mysql *MySQL) PrepareGetColumnsOfTableStmt() (err error) {
+func (mysql *MySQL) PrepareGetColumnsOfTableStmt(ctx context.Context) (err error) {
 
-	mysql.GetColumnsOfTableStmt, err = mysql.Preparex(`
+	mysql.GetColumnsOfTableStmt, err = mysql.PreparexContext(ctx, columnsQueryMySQL("", `
+		WHERE table_name = ?
+		AND table_schema = ?
+		ORDER BY ordinal_position
+	`))
+
+	return err
+}
+
+// columnsQueryMySQL returns the query of the columns with the given condition
+// and order. The table columns, if given, get selected in front of the others.
+func columnsQueryMySQL(tableColumns, conditionAndOrder string) string {
+	return `
 		SELECT
+		  ` + tableColumns + `
 		  ordinal_position AS ordinal_position,
 		  column_name AS column_name,
 		  data_type AS data_type,
@@ -83,37 +126,55 @@ func (mysql *MySQL) PrepareGetColumnsOfTableStmt() (err error) {
 		  is_nullable AS is_nullable,
 		  character_maximum_length AS character_maximum_length,
 		  numeric_precision AS numeric_precision,
+		  column_type AS column_type,
 		  column_key AS column_key,
-		  extra AS extra
+		  extra AS extra,
+		  column_comment AS column_comment,
+		  column_key = 'PRI' AS is_primary_key
 		FROM information_schema.columns
-		WHERE table_name = ?
-		AND table_schema = ?
-		ORDER BY ordinal_position
-	`)
-
-	return err
+` + conditionAndOrder
 }
 
 // GetColumnsOfTable executes the statement for retrieving the columns of a
 // specific table for a given database.
-func (mysql *MySQL) GetColumnsOfTable(table *Table) (err error) {
+func (mysql *MySQL) GetColumnsOfTable(ctx context.Context, table *Table) (err error) {
+	return mysql.getColumnsOfTable(ctx, table, schemaOf(table, mysql.database()))
+}
 
-	err = mysql.GetColumnsOfTableStmt.Select(&table.Columns, table.Name, mysql.DbName)
+// GetColumnsOfTables selects the columns of all given tables in a single
+// query.
+func (mysql *MySQL) GetColumnsOfTables(ctx context.Context, tables []*Table) (err error) {
+	return mysql.getColumnsOfTables(ctx, columnsQueryMySQL("table_name AS table_name, table_schema AS table_schema,", `
+		WHERE table_schema IN (?)
+		ORDER BY table_schema, table_name, ordinal_position
+	`), tables, mysql.Schemas(), mysql.database())
+}
 
-	if mysql.Settings.Verbose {
-		if err != nil {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> schema: %q\r\n", mysql.Schema)
-			fmt.Printf("> dbName: %q\r\n", mysql.DbName)
-		}
-	}
+// GetForeignKeysOfTable gets the foreign keys of a specific table in a given
+// database.
+func (mysql *MySQL) GetForeignKeysOfTable(ctx context.Context, table *Table) (err error) {
+	return mysql.getForeignKeysOfTable(ctx, `
+		SELECT
+		  column_name AS column_name,
+		  referenced_table_name AS referenced_table_name,
+		  referenced_column_name AS referenced_column_name
+		FROM information_schema.key_column_usage
+		WHERE referenced_table_name IS NOT NULL
+		AND table_name = ?
+		AND table_schema = ?
+		ORDER BY constraint_name, ordinal_position
+	`, table, schemaOf(table, mysql.database()))
+}
 
-	return err
+// GetRoutines is not supported for MySQL yet, the result sets of its
+// procedures are not described by the information_schema.
+func (mysql *MySQL) GetRoutines(_ context.Context) (routines []*Table, err error) {
+	return nil, nil
 }
 
 // IsPrimaryKey checks if the column belongs to the primary key.
 func (mysql *MySQL) IsPrimaryKey(column Column) bool {
-	return strings.Contains(column.ColumnKey, "PRI")
+	return column.IsPrimaryKey || strings.Contains(column.ColumnKey, "PRI")
 }
 
 // IsAutoIncrement checks if the column is an auto_increment column.
@@ -139,8 +200,14 @@ func (mysql *MySQL) IsString(column Column) bool {
 // GetTextDatatypes returns the text datatypes for the MySQL database.
 func (mysql *MySQL) GetTextDatatypes() []string {
 	return []string{
+		"tinytext",
 		"text",
+		"mediumtext",
+		"longtext",
+		"tinyblob",
 		"blob",
+		"mediumblob",
+		"longblob",
 	}
 }
 
@@ -150,6 +217,7 @@ func (mysql *MySQL) IsText(column Column) bool {
 }
 
 // GetIntegerDatatypes returns the integer datatypes for the MySQL database.
+// A year is a 4-digit integer rather than a point in time.
 func (mysql *MySQL) GetIntegerDatatypes() []string {
 	return []string{
 		"tinyint",
@@ -157,6 +225,7 @@ func (mysql *MySQL) GetIntegerDatatypes() []string {
 		"mediumint",
 		"int",
 		"bigint",
+		"year",
 	}
 }
 
@@ -188,7 +257,6 @@ func (mysql *MySQL) GetTemporalDatatypes() []string {
 		"timestamp",
 		"date",
 		"datetime",
-		"year",
 	}
 }
 
@@ -196,3 +264,50 @@ func (mysql *MySQL) GetTemporalDatatypes() []string {
 func (mysql *MySQL) IsTemporal(column Column) bool {
 	return isStringInSlice(column.DataType, mysql.GetTemporalDatatypes())
 }
+
+// GetBitDatatypes returns the bit datatypes for the MySQL database.
+func (mysql *MySQL) GetBitDatatypes() []string {
+	return []string{
+		"bit",
+	}
+}
+
+// IsBit returns true if colum is of type bit for the MySQL database.
+func (mysql *MySQL) IsBit(column Column) bool {
+	return isStringInSlice(column.DataType, mysql.GetBitDatatypes())
+}
+
+// GetJSONDatatypes returns the JSON datatypes for the MySQL database.
+func (mysql *MySQL) GetJSONDatatypes() []string {
+	return []string{
+		"json",
+	}
+}
+
+// IsJSON returns true if colum is of type JSON for the MySQL database.
+func (mysql *MySQL) IsJSON(column Column) bool {
+	return isStringInSlice(column.DataType, mysql.GetJSONDatatypes())
+}
+
+// GetArrayDatatypes returns the array datatypes for the MySQL database.
+func (mysql *MySQL) GetArrayDatatypes() []string {
+	return []string{}
+}
+
+// IsArray returns true if colum is of type array for the MySQL database.
+func (mysql *MySQL) IsArray(column Column) bool {
+	return isStringInSlice(column.DataType, mysql.GetArrayDatatypes())
+}
+
+// GetEnumDatatypes returns the enum datatypes for the MySQL database. The
+// allowed values are given by the column_type of the column.
+func (mysql *MySQL) GetEnumDatatypes() []string {
+	return []string{
+		"enum",
+	}
+}
+
+// IsEnum returns true if colum is of type enum for the MySQL database.
+func (mysql *MySQL) IsEnum(column Column) bool {
+	return isStringInSlice(column.DataType, mysql.GetEnumDatatypes())
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -28,6 +29,94 @@ func TestMySQL_DSN(t *testing.T) {
 				return "root:mysecretpassword@tcp(127.0.0.1:3306)/my-cool-db"
 			},
 		},
+		{
+			desc: "ssl mode given, with tls",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.Pswd = "mysecretpassword"
+				s.DbName = "my-cool-db"
+				s.Port = "3306"
+				s.SSL = settings.SSLModeRequire
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return "root:mysecretpassword@tcp(127.0.0.1:3306)/my-cool-db?tls=skip-verify"
+			},
+		},
+		{
+			desc: "ssl mode verify-full given, with verified tls",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.Pswd = "mysecretpassword"
+				s.DbName = "my-cool-db"
+				s.Port = "3306"
+				s.SSL = settings.SSLModeVerifyFull
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return "root:mysecretpassword@tcp(127.0.0.1:3306)/my-cool-db?tls=true"
+			},
+		},
+		{
+			desc: "ssl mode and timeout given, with both params",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.Pswd = "mysecretpassword"
+				s.DbName = "my-cool-db"
+				s.Port = "3306"
+				s.SSL = settings.SSLModeRequire
+				s.Timeout = 5 * time.Second
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return "root:mysecretpassword@tcp(127.0.0.1:3306)/my-cool-db?tls=skip-verify&timeout=5s"
+			},
+		},
+		{
+			desc: "timeout given, with socket",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.Pswd = "mysecretpassword"
+				s.DbName = "my-cool-db"
+				s.Socket = "/tmp/mysql.sock"
+				s.Timeout = 5 * time.Second
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return "root:mysecretpassword@unix(/tmp/mysql.sock)/my-cool-db?timeout=5s"
+			},
+		},
+		{
+			desc: "multiple databases given, connects to the first one",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.Pswd = "mysecretpassword"
+				s.DbName = "my-cool-db,audit"
+				s.Port = "3306"
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return "root:mysecretpassword@tcp(127.0.0.1:3306)/my-cool-db"
+			},
+		},
+		{
+			desc: "dsn given, used as is",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.Socket = "/tmp/mysql.sock"
+				s.DataSourceName = "admin:secret@tcp(db:3306)/app?parseTime=true"
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return "admin:secret@tcp(db:3306)/app?parseTime=true"
+			},
+		},
 		{
 			desc: "username given, with socket",
 			settings: func() *settings.Settings {
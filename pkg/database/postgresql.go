@@ -1,9 +1,13 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/fraenky8/tables-to-go/pkg/settings"
 
 	// postgres database driver
@@ -15,6 +19,10 @@ type Postgresql struct {
 	*GeneralDatabase
 
 	defaultUserName string
+
+	// tableRegclass is the expression of the regclass of the table of the
+	// columns to look up their comments
+	tableRegclass string
 }
 
 // NewPostgresql creates a new Postgresql database.
@@ -25,6 +33,7 @@ func NewPostgresql(s *settings.Settings) *Postgresql {
 			driver:   dbTypeToDriverMap[s.DbType],
 		},
 		defaultUserName: "postgres",
+		tableRegclass:   "format('%I.%I', ic.table_schema, ic.table_name)::regclass",
 	}
 }
 
@@ -34,47 +43,60 @@ func (pg *Postgresql) Connect() error {
 	return pg.GeneralDatabase.Connect(pg.DSN())
 }
 
-// DSN creates the DSN String to connect to this database.
+// DSN creates the DSN String to connect to this database, unless given by
+// the settings.
 func (pg *Postgresql) DSN() string {
+	if pg.Settings.DataSourceName != "" {
+		return pg.Settings.DataSourceName
+	}
 	user := pg.defaultUserName
 	if pg.Settings.User != "" {
 		user = pg.Settings.User
 	}
+	var dsn string
 	if pg.Settings.Socket != "" {
-		return fmt.Sprintf("host=%s user=%s dbname=%s password=%s",
+		dsn = fmt.Sprintf("host=%s user=%s dbname=%s password=%s",
 			pg.Settings.Socket, user, pg.Settings.DbName, pg.Settings.Pswd)
+	} else {
+		sslMode := pg.Settings.SSL
+		if sslMode == "" {
+			sslMode = settings.SSLModeDisable
+		}
+		dsn = fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s",
+			pg.Settings.Host, pg.Settings.Port, user, pg.Settings.DbName, pg.Settings.Pswd, sslMode)
 	}
-	return fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable",
-		pg.Settings.Host, pg.Settings.Port, user, pg.Settings.DbName, pg.Settings.Pswd)
+	if pg.Settings.Timeout > 0 {
+		// connect_timeout is given in whole seconds
+		dsn += fmt.Sprintf(" connect_timeout=%d", int(math.Ceil(pg.Settings.Timeout.Seconds())))
+	}
+	return dsn
 }
 
-// GetTables gets all tables for a given schema by name.
-func (pg *Postgresql) GetTables() (tables []*Table, err error) {
-
-	err = pg.Select(&tables, `
-		SELECT table_name
+// GetTables gets all tables, and views if enabled, for the given schemas by name.
+func (pg *Postgresql) GetTables(ctx context.Context) (tables []*Table, err error) {
+	return pg.getTables(ctx, `
+		SELECT table_name, table_schema
 		FROM information_schema.tables
-		WHERE table_type = 'BASE TABLE'
-		AND table_schema = $1
-		ORDER BY table_name
-	`, pg.Schema)
-
-	if pg.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> schema: %q\r\n", pg.Schema)
-		}
-	}
-
-	return tables, err
+		WHERE table_type IN (`+pg.tableTypes()+`)
+		AND table_schema IN (?)
+		ORDER BY table_schema, table_name
+	`, pg.Schemas())
 }
 
-// PrepareGetColumnsOfTableStmt prepares the statement for retrieving the
-// columns of a specific table for a given database.
-func (pg *Postgresql) PrepareGetColumnsOfTableStmt() (err error) {
+// columnsQuery returns the query of the columns with the given condition and
+// order. The table columns, if given, get selected in front of the others. The
+// data type of a column of a domain is the one of the underlying base type.
+func (pg *Postgresql) columnsQuery(tableColumns, conditionAndOrder string) string {
 
-	pg.GetColumnsOfTableStmt, err = pg.Preparex(`
+	// the comments are only looked up in the catalog if needed
+	columnComment := "NULL"
+	if pg.Comments {
+		columnComment = "col_description(" + pg.tableRegclass + ", ic.ordinal_position)"
+	}
+
+	return `
 		SELECT
+			` + tableColumns + `
 			ic.ordinal_position,
 			ic.column_name,
 			ic.data_type,
@@ -82,6 +104,19 @@ func (pg *Postgresql) PrepareGetColumnsOfTableStmt() (err error) {
 			ic.is_nullable,
 			ic.character_maximum_length,
 			ic.numeric_precision,
+			ic.udt_name,
+			ic.domain_name,
+			` + columnComment + ` AS column_comment,
+			EXISTS (
+				SELECT 1
+				FROM information_schema.table_constraints AS ptc
+					JOIN information_schema.key_column_usage AS pkcu ON ptc.constraint_schema = pkcu.constraint_schema
+					AND ptc.constraint_name = pkcu.constraint_name
+				WHERE ptc.constraint_type = 'PRIMARY KEY'
+				AND ptc.table_schema = ic.table_schema
+				AND ptc.table_name = ic.table_name
+				AND pkcu.column_name = ic.column_name
+			) AS is_primary_key,
 			itc.constraint_name,
 			itc.constraint_type
 		FROM information_schema.columns AS ic
@@ -91,33 +126,131 @@ func (pg *Postgresql) PrepareGetColumnsOfTableStmt() (err error) {
 			LEFT JOIN information_schema.table_constraints AS itc ON ic.table_name = itc.table_name
 			AND ic.table_schema = itc.table_schema
 			AND ikcu.constraint_name = itc.constraint_name
+` + conditionAndOrder
+}
+
+// PrepareGetColumnsOfTableStmt prepares the statement for retrieving the
+// columns of a specific table for a given database.
+func (pg *Postgresql) PrepareGetColumnsOfTableStmt(ctx context.Context) (err error) {
+
+	pg.GetColumnsOfTableStmt, err = pg.PreparexContext(ctx, pg.columnsQuery("", `
 		WHERE ic.table_name = $1
 		AND ic.table_schema = $2
 		ORDER BY ic.ordinal_position
-	`)
+	`))
 
 	return err
 }
 
 // GetColumnsOfTable executes the statement for retrieving the columns of a
 // specific table in a given schema.
-func (pg *Postgresql) GetColumnsOfTable(table *Table) (err error) {
+func (pg *Postgresql) GetColumnsOfTable(ctx context.Context, table *Table) (err error) {
+	return pg.getColumnsOfTable(ctx, table, schemaOf(table, pg.Schema))
+}
+
+// GetColumnsOfTables selects the columns of all given tables in a single
+// query.
+func (pg *Postgresql) GetColumnsOfTables(ctx context.Context, tables []*Table) (err error) {
+	return pg.getColumnsOfTables(ctx, pg.columnsQuery("ic.table_name, ic.table_schema,", `
+		WHERE ic.table_schema IN (?)
+		ORDER BY ic.table_schema, ic.table_name, ic.ordinal_position
+	`), tables, pg.Schemas(), pg.Schema)
+}
+
+// GetForeignKeysOfTable gets the foreign keys of a specific table in a given
+// schema.
+func (pg *Postgresql) GetForeignKeysOfTable(ctx context.Context, table *Table) (err error) {
+	return pg.getForeignKeysOfTable(ctx, `
+		SELECT
+			kcu.column_name,
+			rkcu.table_name AS referenced_table_name,
+			rkcu.column_name AS referenced_column_name
+		FROM information_schema.referential_constraints AS rc
+			JOIN information_schema.key_column_usage AS kcu ON rc.constraint_schema = kcu.constraint_schema
+			AND rc.constraint_name = kcu.constraint_name
+			JOIN information_schema.key_column_usage AS rkcu ON rc.unique_constraint_schema = rkcu.constraint_schema
+			AND rc.unique_constraint_name = rkcu.constraint_name
+			AND kcu.position_in_unique_constraint = rkcu.ordinal_position
+		WHERE kcu.table_name = $1
+		AND kcu.table_schema = $2
+		ORDER BY kcu.constraint_name, kcu.ordinal_position
+	`, table, schemaOf(table, pg.Schema))
+}
 
-	err = pg.GetColumnsOfTableStmt.Select(&table.Columns, table.Name, pg.Schema)
+// GetRoutines gets the functions returning sets of rows for the given schemas
+// by name, ie. the functions declared as RETURNS SETOF or RETURNS TABLE. The
+// OUT parameters, as well as the columns of RETURNS TABLE, make up the columns
+// of the result. Only the first of overloaded functions, by oid, is kept.
+func (pg *Postgresql) GetRoutines(ctx context.Context) (routines []*Table, err error) {
+
+	query, args, err := sqlx.In(`
+		SELECT
+			p.proname AS table_name,
+			n.nspname AS table_schema,
+			p.oid AS routine_oid,
+			COALESCE(NULLIF(a.name, ''), 'column' || a.position) AS column_name,
+			CASE
+				WHEN t.typelem <> 0 AND t.typlen = -1 THEN 'ARRAY'
+				WHEN t.typtype IN ('c', 'e') THEN 'USER-DEFINED'
+				ELSE format_type(a.type, NULL)
+			END AS data_type,
+			t.typname AS udt_name
+		FROM pg_catalog.pg_proc AS p
+			JOIN pg_catalog.pg_namespace AS n ON n.oid = p.pronamespace
+			CROSS JOIN LATERAL unnest(p.proallargtypes, p.proargmodes, p.proargnames)
+				WITH ORDINALITY AS a(type, mode, name, position)
+			JOIN pg_catalog.pg_type AS t ON t.oid = a.type
+		WHERE p.proretset
+		AND n.nspname IN (?)
+		AND a.mode IN ('o', 'b', 't')
+		ORDER BY n.nspname, p.proname, p.oid, a.position
+	`, pg.Schemas())
+
+	var rows []struct {
+		Name   string `db:"table_name"`
+		Schema string `db:"table_schema"`
+		Oid    uint32 `db:"routine_oid"`
+		Column
+	}
+	if err == nil {
+		err = pg.SelectContext(ctx, &rows, pg.Rebind(query), args...)
+	}
 
-	if pg.Verbose {
-		if err != nil {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> schema: %q\r\n", pg.Schema)
+	if err != nil {
+		pg.log().Debugf("> Error at GetRoutines()\r\n")
+		pg.log().Debugf("> schemas: %q\r\n", pg.Schemas())
+		return nil, err
+	}
+
+	oids := map[string]uint32{}
+
+	for _, row := range rows {
+		key := row.Schema + "." + row.Name
+		if oid, ok := oids[key]; ok && oid != row.Oid {
+			continue
+		}
+		if _, ok := oids[key]; !ok {
+			oids[key] = row.Oid
+			routines = append(routines, &Table{
+				Name:    row.Name,
+				Schema:  row.Schema,
+				Routine: true,
+			})
 		}
+
+		// the columns of a result can always be NULL
+		routine := routines[len(routines)-1]
+		row.Column.OrdinalPosition = len(routine.Columns) + 1
+		row.Column.IsNullable = "YES"
+		routine.Columns = append(routine.Columns, row.Column)
 	}
 
-	return err
+	return routines, nil
 }
 
 // IsPrimaryKey checks if the column belongs to the primary key.
 func (pg *Postgresql) IsPrimaryKey(column Column) bool {
-	return strings.Contains(column.ConstraintType.String, "PRIMARY KEY")
+	return column.IsPrimaryKey || strings.Contains(column.ConstraintType.String, "PRIMARY KEY")
 }
 
 // IsAutoIncrement checks if the column is an auto_increment column.
@@ -202,3 +335,52 @@ func (pg *Postgresql) GetTemporalDatatypes() []string {
 func (pg *Postgresql) IsTemporal(column Column) bool {
 	return isStringInSlice(column.DataType, pg.GetTemporalDatatypes())
 }
+
+// GetBitDatatypes returns the bit datatypes for the Postgresql database.
+func (pg *Postgresql) GetBitDatatypes() []string {
+	return []string{
+		"bit",
+		"bit varying",
+	}
+}
+
+// IsBit returns true if colum is of type bit for the Postgresql database.
+func (pg *Postgresql) IsBit(column Column) bool {
+	return isStringInSlice(column.DataType, pg.GetBitDatatypes())
+}
+
+// GetJSONDatatypes returns the JSON datatypes for the Postgresql database.
+func (pg *Postgresql) GetJSONDatatypes() []string {
+	return []string{
+		"json",
+		"jsonb",
+	}
+}
+
+// IsJSON returns true if colum is of type JSON for the Postgresql database.
+func (pg *Postgresql) IsJSON(column Column) bool {
+	return isStringInSlice(column.DataType, pg.GetJSONDatatypes())
+}
+
+// GetArrayDatatypes returns the array datatypes for the Postgresql database.
+// The type of the elements is given by the udt_name of the column.
+func (pg *Postgresql) GetArrayDatatypes() []string {
+	return []string{
+		"ARRAY",
+	}
+}
+
+// IsArray returns true if colum is of type array for the Postgresql database.
+func (pg *Postgresql) IsArray(column Column) bool {
+	return isStringInSlice(column.DataType, pg.GetArrayDatatypes())
+}
+
+// GetEnumDatatypes returns the enum datatypes for the Postgresql database.
+func (pg *Postgresql) GetEnumDatatypes() []string {
+	return []string{}
+}
+
+// IsEnum returns true if colum is of type enum for the Postgresql database.
+func (pg *Postgresql) IsEnum(column Column) bool {
+	return isStringInSlice(column.DataType, pg.GetEnumDatatypes())
+}
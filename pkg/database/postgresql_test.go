@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -40,6 +41,45 @@ func TestPostgresql_DSN(t *testing.T) {
 					s.Host, s.Port, "my_custom_user", s.DbName, s.Pswd)
 			},
 		},
+		{
+			desc: "with given ssl mode, sslmode gets overwritten",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypePostgresql
+				s.SSL = settings.SSLModeVerifyFull
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=verify-full",
+					s.Host, s.Port, "postgres", s.DbName, s.Pswd)
+			},
+		},
+		{
+			desc: "with given dsn, it is used as is",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypePostgresql
+				s.User = "my_custom_user"
+				s.DataSourceName = "postgres://admin@db:5432/app?application_name=tables-to-go&search_path=audit"
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return "postgres://admin@db:5432/app?application_name=tables-to-go&search_path=audit"
+			},
+		},
+		{
+			desc: "with given timeout, connect_timeout is rounded up to seconds",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypePostgresql
+				s.Timeout = 1500 * time.Millisecond
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable connect_timeout=2",
+					s.Host, s.Port, "postgres", s.DbName, s.Pswd)
+			},
+		},
 		{
 			desc: "with given username and socket, default gets overwritten",
 			settings: func() *settings.Settings {
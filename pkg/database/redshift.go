@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// Redshift implements the Database interface for Amazon Redshift. It speaks
+// the Postgresql wire protocol and provides the information_schema of
+// Postgresql 8, so it reuses the queries of Postgresql. Next to its own
+// datatypes, eg. `super`, Redshift distributes and sorts the rows of the
+// tables by key columns, which are looked up along with the comments.
+type Redshift struct {
+	*Postgresql
+}
+
+// NewRedshift creates a new Redshift database.
+func NewRedshift(s *settings.Settings) *Redshift {
+	pg := NewPostgresql(s)
+	pg.defaultUserName = "awsuser"
+	// format() is not supported by Redshift
+	pg.tableRegclass = "(quote_ident(ic.table_schema) || '.' || quote_ident(ic.table_name))::regclass"
+	return &Redshift{
+		Postgresql: pg,
+	}
+}
+
+// tableKey is a distribution or sort key column of a table.
+type tableKey struct {
+	TableName    string `db:"table_name"`
+	TableSchema  string `db:"table_schema"`
+	ColumnName   string `db:"column_name"`
+	DistKey      bool   `db:"is_dist_key"`
+	SortKeyOrder int    `db:"sort_key_order"`
+}
+
+// GetColumnsOfTable executes the statement for retrieving the columns of a
+// specific table in a given schema. If comments are enabled, the distribution
+// and sort keys are looked up, too.
+func (r *Redshift) GetColumnsOfTable(ctx context.Context, table *Table) (err error) {
+	if err = r.Postgresql.GetColumnsOfTable(ctx, table); err != nil {
+		return err
+	}
+	if !r.Comments {
+		return nil
+	}
+	return r.getKeysOfTables(ctx, []*Table{table}, []string{schemaOf(table, r.Schema)}, []string{table.Name})
+}
+
+// GetColumnsOfTables selects the columns of all given tables in a single
+// query. If comments are enabled, the distribution and sort keys are looked
+// up, too.
+func (r *Redshift) GetColumnsOfTables(ctx context.Context, tables []*Table) (err error) {
+	if err = r.Postgresql.GetColumnsOfTables(ctx, tables); err != nil {
+		return err
+	}
+	if !r.Comments {
+		return nil
+	}
+	return r.getKeysOfTables(ctx, tables, r.Schemas(), nil)
+}
+
+// keysQuery returns the statement, with its arguments, for retrieving the
+// distribution and sort keys of the tables in the given schemas. If names of
+// tables are given, only the keys of these tables are retrieved.
+func (r *Redshift) keysQuery(schemas, tableNames []string) (string, []interface{}, error) {
+	query := `
+		SELECT
+			c.relname AS table_name,
+			n.nspname AS table_schema,
+			a.attname AS column_name,
+			a.attisdistkey AS is_dist_key,
+			a.attsortkeyord AS sort_key_order
+		FROM pg_catalog.pg_attribute AS a
+			JOIN pg_catalog.pg_class AS c ON a.attrelid = c.oid
+			JOIN pg_catalog.pg_namespace AS n ON c.relnamespace = n.oid
+		WHERE n.nspname IN (?)
+		AND a.attnum > 0
+		AND (a.attisdistkey OR a.attsortkeyord <> 0)
+	`
+	args := []interface{}{schemas}
+	if len(tableNames) > 0 {
+		query += "AND c.relname IN (?)\n"
+		args = append(args, tableNames)
+	}
+
+	return sqlx.In(query, args...)
+}
+
+// getKeysOfTables sets the distribution and sort keys of the columns of the
+// given tables in the given schemas. They are given by the catalog only. If
+// names of tables are given, the lookup is restricted to these tables.
+func (r *Redshift) getKeysOfTables(ctx context.Context, tables []*Table, schemas, tableNames []string) (err error) {
+
+	var keys []tableKey
+
+	query, args, err := r.keysQuery(schemas, tableNames)
+	if err == nil {
+		err = r.SelectContext(ctx, &keys, r.Rebind(query), args...)
+	}
+
+	if err != nil {
+		r.log().Debugf("> Error at getKeysOfTables()\r\n")
+		r.log().Debugf("> schemas: %q\r\n", schemas)
+		r.log().Debugf("> tables: %q\r\n", tableNames)
+		return err
+	}
+
+	keysOfColumns := map[string]tableKey{}
+	for _, key := range keys {
+		keysOfColumns[key.TableSchema+"."+key.TableName+"."+key.ColumnName] = key
+	}
+
+	for _, table := range tables {
+		prefix := schemaOf(table, r.Schema) + "." + table.Name + "."
+		for i, column := range table.Columns {
+			if key, ok := keysOfColumns[prefix+column.Name]; ok {
+				table.Columns[i].DistKey = key.DistKey
+				table.Columns[i].SortKeyOrder = key.SortKeyOrder
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetJSONDatatypes returns the JSON datatypes for the Redshift database. The
+// semi-structured super values are given as JSON.
+func (r *Redshift) GetJSONDatatypes() []string {
+	return append(r.Postgresql.GetJSONDatatypes(),
+		"super",
+	)
+}
+
+// IsJSON returns true if colum is of type JSON for the Redshift database.
+func (r *Redshift) IsJSON(column Column) bool {
+	return isStringInSlice(column.DataType, r.GetJSONDatatypes())
+}
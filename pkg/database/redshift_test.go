@@ -0,0 +1,75 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestRedshift_DSN(t *testing.T) {
+	s := settings.New()
+	s.DbType = settings.DBTypeRedshift
+	s.Port = "5439"
+
+	db := NewRedshift(s)
+
+	expected := fmt.Sprintf("host=%s port=5439 user=awsuser dbname=%s password=%s sslmode=disable",
+		s.Host, s.DbName, s.Pswd)
+	assert.Equal(t, expected, db.DSN())
+}
+
+func TestRedshift_Datatypes(t *testing.T) {
+	db := NewRedshift(settings.New())
+
+	tests := []struct {
+		dataType string
+		is       func(Column) bool
+	}{
+		{dataType: "super", is: db.IsJSON},
+		{dataType: "character varying", is: db.IsString},
+		{dataType: "bigint", is: db.IsInteger},
+		{dataType: "double precision", is: db.IsFloat},
+		{dataType: "timestamp with time zone", is: db.IsTemporal},
+	}
+	for _, test := range tests {
+		t.Run(test.dataType, func(t *testing.T) {
+			assert.True(t, test.is(Column{DataType: test.dataType}))
+		})
+	}
+}
+
+func TestRedshift_ColumnsQueryComments(t *testing.T) {
+	s := settings.New()
+	s.DbType = settings.DBTypeRedshift
+	s.Comments = true
+
+	query := NewRedshift(s).columnsQuery("", "")
+
+	assert.Contains(t, query, "col_description((quote_ident(ic.table_schema) || '.' || quote_ident(ic.table_name))::regclass, ic.ordinal_position)")
+	assert.NotContains(t, query, "format(")
+}
+
+func TestRedshift_KeysQuery(t *testing.T) {
+	db := NewRedshift(settings.New())
+
+	t.Run("single table restricts the keys to its name", func(t *testing.T) {
+		query, args, err := db.keysQuery([]string{"public"}, []string{"users"})
+		if err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+		assert.Contains(t, query, "AND c.relname IN (?)")
+		assert.Equal(t, []interface{}{"public", "users"}, args)
+	})
+
+	t.Run("batch retrieves the keys of the whole schemas", func(t *testing.T) {
+		query, args, err := db.keysQuery([]string{"public", "sales"}, nil)
+		if err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+		assert.NotContains(t, query, "c.relname IN")
+		assert.Equal(t, []interface{}{"public", "sales"}, args)
+	})
+}
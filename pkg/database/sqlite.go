@@ -1,8 +1,8 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"net/url"
 	"strings"
 
@@ -30,8 +30,12 @@ func (s *SQLite) Connect() (err error) {
 	return s.GeneralDatabase.Connect(s.DSN())
 }
 
-// DSN creates the DSN String to connect to this database.
+// DSN creates the DSN String to connect to this database, unless given by
+// the settings.
 func (s *SQLite) DSN() string {
+	if s.Settings.DataSourceName != "" {
+		return s.Settings.DataSourceName
+	}
 	if s.Settings.User == "" && s.Settings.Pswd == "" {
 		return s.Settings.DbName
 	}
@@ -50,42 +54,44 @@ func (s *SQLite) DSN() string {
 	return strings.ReplaceAll(u.RequestURI(), "_auth=&", "_auth&")
 }
 
-func (s *SQLite) GetTables() (tables []*Table, err error) {
+func (s *SQLite) GetTables(ctx context.Context) (tables []*Table, err error) {
+
+	types := "'table'"
+	if s.Views {
+		types = "'table', 'view'"
+	}
 
-	err = s.Select(&tables, `
+	err = s.SelectContext(ctx, &tables, `
 		SELECT name AS table_name
 		FROM sqlite_master
-		WHERE type = 'table'
+		WHERE type IN (`+types+`)
 		AND name NOT LIKE 'sqlite?_%' escape '?'
 	`)
 
-	if s.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> database: %q\r\n", s.DbName)
-		}
+	if err != nil {
+		s.log().Debugf("> Error at GetTables()\r\n")
+		s.log().Debugf("> database: %q\r\n", s.DbName)
 	}
 
 	return tables, err
 }
 
-func (s *SQLite) PrepareGetColumnsOfTableStmt() (err error) {
+func (s *SQLite) PrepareGetColumnsOfTableStmt(_ context.Context) (err error) {
 	return nil
 }
 
-func (s *SQLite) GetColumnsOfTable(table *Table) (err error) {
+func (s *SQLite) GetColumnsOfTable(ctx context.Context, table *Table) (err error) {
 
-	rows, err := s.Queryx(`
+	rows, err := s.QueryxContext(ctx, `
 		SELECT * 
-		FROM PRAGMA_TABLE_INFO('` + table.Name + `')
+		FROM PRAGMA_TABLE_INFO('`+table.Name+`')
 	`)
 	if err != nil {
-		if s.Verbose {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> database: %q\r\n", s.DbName)
-		}
+		s.log().Debugf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
+		s.log().Debugf("> database: %q\r\n", s.DbName)
 		return err
 	}
+	defer rows.Close()
 
 	type column struct {
 		CID          int            `db:"cid"`
@@ -113,10 +119,12 @@ func (s *SQLite) GetColumnsOfTable(table *Table) (err error) {
 			isPrimaryKey = "PK"
 		}
 
+		// SQLite keeps the declared type as written, e.g. "INTEGER" or
+		// "Text", but the names of the datatypes are case-insensitive.
 		table.Columns = append(table.Columns, Column{
 			OrdinalPosition:        col.CID,
 			Name:                   col.Name,
-			DataType:               col.DataType,
+			DataType:               strings.ToLower(col.DataType),
 			DefaultValue:           col.DefaultValue,
 			IsNullable:             isNullable,
 			CharacterMaximumLength: sql.NullInt64{},
@@ -126,18 +134,72 @@ func (s *SQLite) GetColumnsOfTable(table *Table) (err error) {
 			Extra:          "",
 			ConstraintName: sql.NullString{},
 			ConstraintType: sql.NullString{},
+			// pk is the 1-based index of the column in the primary key
+			IsPrimaryKey: col.PrimaryKey > 0,
 		})
 	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// only a single column primary key can be an alias of the rowid, the
+	// first column of a composite primary key is not assigned by SQLite
+	for _, column := range table.Columns {
+		if column.IsPrimaryKey && column.ColumnKey != "PK" {
+			for i := range table.Columns {
+				table.Columns[i].ColumnKey = ""
+			}
+			break
+		}
+	}
+
+	return nil
+}
 
+// GetColumnsOfTables gets the columns of the given tables one by one, the
+// database is a local file anyway.
+func (s *SQLite) GetColumnsOfTables(ctx context.Context, tables []*Table) (err error) {
+	for _, table := range tables {
+		if err = s.GetColumnsOfTable(ctx, table); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (s *SQLite) GetForeignKeysOfTable(ctx context.Context, table *Table) (err error) {
+
+	// the referenced column is NULL if the primary key of the referenced
+	// table is referenced implicitly
+	err = s.SelectContext(ctx, &table.ForeignKeys, `
+		SELECT
+			"from" AS column_name,
+			"table" AS referenced_table_name,
+			COALESCE("to", '') AS referenced_column_name
+		FROM PRAGMA_FOREIGN_KEY_LIST(?)
+		ORDER BY id, seq
+	`, table.Name)
+
+	if err != nil {
+		s.log().Debugf("> Error at GetForeignKeysOfTable(%v)\r\n", table.Name)
+		s.log().Debugf("> database: %q\r\n", s.DbName)
+	}
+
+	return err
+}
+
+func (s *SQLite) GetRoutines(_ context.Context) (routines []*Table, err error) {
+	return nil, nil
+}
+
 func (s *SQLite) IsPrimaryKey(column Column) bool {
-	return column.ColumnKey == "PK"
+	return column.IsPrimaryKey || column.ColumnKey == "PK"
 }
 
 func (s *SQLite) IsAutoIncrement(column Column) bool {
-	return column.ColumnKey == "PK"
+	// only an INTEGER PRIMARY KEY is an alias of the rowid, which is
+	// assigned by SQLite if omitted on insert
+	return column.ColumnKey == "PK" && column.DataType == "integer"
 }
 
 func (s *SQLite) GetStringDatatypes() []string {
@@ -188,3 +250,35 @@ func (s *SQLite) GetTemporalDatatypes() []string {
 func (s *SQLite) IsTemporal(_ Column) bool {
 	return false
 }
+
+func (s *SQLite) GetBitDatatypes() []string {
+	return []string{}
+}
+
+func (s *SQLite) IsBit(_ Column) bool {
+	return false
+}
+
+func (s *SQLite) GetJSONDatatypes() []string {
+	return []string{}
+}
+
+func (s *SQLite) IsJSON(_ Column) bool {
+	return false
+}
+
+func (s *SQLite) GetArrayDatatypes() []string {
+	return []string{}
+}
+
+func (s *SQLite) IsArray(_ Column) bool {
+	return false
+}
+
+func (s *SQLite) GetEnumDatatypes() []string {
+	return []string{}
+}
+
+func (s *SQLite) IsEnum(_ Column) bool {
+	return false
+}
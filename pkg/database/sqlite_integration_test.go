@@ -0,0 +1,253 @@
+//go:build sqlite3
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func newInMemorySQLite(t *testing.T, schema string) *SQLite {
+	t.Helper()
+
+	s := settings.New()
+	s.DbType = settings.DBTypeSQLite
+	s.DbName = "file::memory:?cache=shared"
+
+	db := NewSQLite(s)
+	if err := db.Connect(); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	return db
+}
+
+func TestSQLite_InMemory(t *testing.T) {
+	db := newInMemorySQLite(t, `
+		CREATE TABLE some_user_info (
+			id INTEGER NOT NULL PRIMARY KEY,
+			first_name TEXT,
+			last_name TEXT NOT NULL,
+			height REAL DEFAULT 1.8
+		);
+	`)
+
+	tables, err := db.GetTables(context.Background())
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	if !assert.Len(t, tables, 1) {
+		return
+	}
+	assert.Equal(t, "some_user_info", tables[0].Name)
+
+	assert.NoError(t, db.PrepareGetColumnsOfTableStmt(context.Background()))
+	if err = db.GetColumnsOfTable(context.Background(), tables[0]); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	columns := tables[0].Columns
+	if !assert.Len(t, columns, 4) {
+		return
+	}
+
+	assert.Equal(t, "id", columns[0].Name)
+	assert.True(t, db.IsInteger(columns[0]))
+	assert.True(t, db.IsPrimaryKey(columns[0]))
+	assert.True(t, db.IsAutoIncrement(columns[0]))
+	assert.False(t, db.IsNullable(columns[0]))
+
+	assert.Equal(t, "first_name", columns[1].Name)
+	assert.True(t, db.IsString(columns[1]))
+	assert.True(t, db.IsNullable(columns[1]))
+
+	assert.Equal(t, "last_name", columns[2].Name)
+	assert.True(t, db.IsString(columns[2]))
+	assert.False(t, db.IsNullable(columns[2]))
+
+	assert.Equal(t, "height", columns[3].Name)
+	assert.True(t, db.IsFloat(columns[3]))
+	assert.Equal(t, "1.8", columns[3].DefaultValue.String)
+}
+
+func TestSQLite_CompositePrimaryKey(t *testing.T) {
+	db := newInMemorySQLite(t, `
+		CREATE TABLE user_roles (
+			user_id INTEGER NOT NULL,
+			role_id INTEGER NOT NULL,
+			granted_by INTEGER,
+			PRIMARY KEY (user_id, role_id)
+		);
+	`)
+
+	table := &Table{Name: "user_roles"}
+	if err := db.GetColumnsOfTable(context.Background(), table); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	if !assert.Len(t, table.Columns, 3) {
+		return
+	}
+
+	assert.True(t, db.IsPrimaryKey(table.Columns[0]))
+	assert.True(t, db.IsPrimaryKey(table.Columns[1]))
+	assert.False(t, db.IsPrimaryKey(table.Columns[2]))
+
+	assert.False(t, db.IsAutoIncrement(table.Columns[0]))
+	assert.False(t, db.IsAutoIncrement(table.Columns[1]))
+}
+
+func TestSQLite_TextPrimaryKey(t *testing.T) {
+	db := newInMemorySQLite(t, `
+		CREATE TABLE codes (
+			code TEXT NOT NULL PRIMARY KEY,
+			label TEXT
+		);
+	`)
+
+	table := &Table{Name: "codes"}
+	if err := db.GetColumnsOfTable(context.Background(), table); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	if !assert.Len(t, table.Columns, 2) {
+		return
+	}
+
+	assert.True(t, db.IsPrimaryKey(table.Columns[0]))
+	assert.False(t, db.IsAutoIncrement(table.Columns[0]))
+}
+
+func TestSQLite_GetTablesViews(t *testing.T) {
+	db := newInMemorySQLite(t, `
+		CREATE TABLE user_account (
+			id INTEGER NOT NULL PRIMARY KEY,
+			name TEXT NOT NULL
+		);
+		CREATE VIEW user_name AS SELECT name FROM user_account;
+	`)
+
+	tables, err := db.GetTables(context.Background())
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	if assert.Len(t, tables, 1) {
+		assert.Equal(t, "user_account", tables[0].Name)
+	}
+
+	db.Views = true
+
+	tables, err = db.GetTables(context.Background())
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	if !assert.Len(t, tables, 2) {
+		return
+	}
+	assert.Equal(t, "user_account", tables[0].Name)
+	assert.Equal(t, "user_name", tables[1].Name)
+
+	if err = db.GetColumnsOfTable(context.Background(), tables[1]); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	if assert.Len(t, tables[1].Columns, 1) {
+		assert.Equal(t, "name", tables[1].Columns[0].Name)
+		assert.False(t, db.IsPrimaryKey(tables[1].Columns[0]))
+	}
+}
+
+func TestSQLite_GetForeignKeysOfTable(t *testing.T) {
+	db := newInMemorySQLite(t, `
+		CREATE TABLE user_group (
+			id INTEGER NOT NULL PRIMARY KEY
+		);
+		CREATE TABLE user_account (
+			id INTEGER NOT NULL PRIMARY KEY,
+			group_id INTEGER REFERENCES user_group (id),
+			parent_group_id INTEGER REFERENCES user_group
+		);
+	`)
+
+	table := &Table{Name: "user_account"}
+	if err := db.GetForeignKeysOfTable(context.Background(), table); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	assert.ElementsMatch(t, []ForeignKey{
+		{ColumnName: "group_id", ReferencedTable: "user_group", ReferencedColumn: "id"},
+		{ColumnName: "parent_group_id", ReferencedTable: "user_group", ReferencedColumn: ""},
+	}, table.ForeignKeys)
+}
+
+func TestSQLite_GetColumnsOfTables(t *testing.T) {
+	db := newInMemorySQLite(t, `
+		CREATE TABLE users (
+			id INTEGER NOT NULL PRIMARY KEY,
+			name TEXT
+		);
+		CREATE TABLE posts (
+			id INTEGER NOT NULL PRIMARY KEY
+		);
+	`)
+
+	tables, err := db.GetTables(context.Background())
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	if err = db.GetColumnsOfTables(context.Background(), tables); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	numColumns := map[string]int{}
+	for _, table := range tables {
+		numColumns[table.Name] = len(table.Columns)
+	}
+	assert.Equal(t, map[string]int{"users": 2, "posts": 1}, numColumns)
+}
+
+func TestGeneralDatabase_getColumnsOfTables(t *testing.T) {
+	db := newInMemorySQLite(t, `
+		CREATE TABLE columns (
+			table_schema TEXT,
+			table_name TEXT,
+			ordinal_position INTEGER,
+			column_name TEXT
+		);
+		INSERT INTO columns VALUES
+			('main', 'users', 1, 'id'),
+			('main', 'users', 2, 'name'),
+			('main', 'posts', 1, 'id'),
+			('main', 'skipped', 1, 'id'),
+			('audit', 'users', 1, 'changed_at');
+	`)
+
+	users := &Table{Name: "users", Schema: "main"}
+	auditUsers := &Table{Name: "users", Schema: "audit"}
+	posts := &Table{Name: "posts"}
+	tags := &Table{Name: "tags", Schema: "main"}
+
+	err := db.getColumnsOfTables(context.Background(), `
+		SELECT table_name, table_schema, ordinal_position, column_name
+		FROM columns
+		WHERE table_schema IN (?)
+		ORDER BY table_schema, table_name, ordinal_position
+	`, []*Table{users, auditUsers, posts, tags}, []string{"main", "audit"}, "main")
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	assert.Equal(t, []Column{{OrdinalPosition: 1, Name: "id"}, {OrdinalPosition: 2, Name: "name"}}, users.Columns)
+	assert.Equal(t, []Column{{OrdinalPosition: 1, Name: "changed_at"}}, auditUsers.Columns)
+	assert.Equal(t, []Column{{OrdinalPosition: 1, Name: "id"}}, posts.Columns)
+	assert.Empty(t, tags.Columns)
+}
@@ -27,6 +27,19 @@ func TestSQLite_DSN(t *testing.T) {
 			expected: "path/to/a/file.db",
 			isError:  assert.NoError,
 		},
+		{
+			desc: "with given dsn, it is used as is",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeSQLite
+				s.DbName = "path/to/a/file.db"
+				s.User = "username"
+				s.DataSourceName = "file:path/to/a/file.db?mode=ro"
+				return s
+			},
+			expected: "file:path/to/a/file.db?mode=ro",
+			isError:  assert.NoError,
+		},
 		{
 			desc: "with given username, authentication is enabled in DNS string",
 			settings: func() *settings.Settings {
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/fraenky8/tables-to-go/internal/cli"
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/logger"
+	"github.com/fraenky8/tables-to-go/pkg/output"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// Generate verifies the given settings, connects to the database and writes
+// the structs of the tables as configured by the settings. If enabled, the
+// written code gets type checked afterwards. This is the same as running the
+// command line tool with the respective flags.
+func Generate(s *settings.Settings) error {
+	return GenerateContext(context.Background(), s)
+}
+
+// GenerateContext works like Generate. The queries of the database get
+// cancelled along with the given context.
+func GenerateContext(ctx context.Context, s *settings.Settings) error {
+
+	if err := s.Verify(); err != nil {
+		return err
+	}
+
+	db := database.New(s)
+
+	if err := db.Connect(); err != nil {
+		return err
+	}
+	defer db.Close()
+
+	out := newWriter(s)
+
+	if err := cli.RunContext(ctx, s, db, out); err != nil {
+		return fmt.Errorf("run error: %w", err)
+	}
+
+	// only the packages written into get checked, not the whole output path
+	if recorder, ok := out.(output.Recorder); ok && s.VerifyOutput {
+		if err := output.Check(recorder.Files()); err != nil {
+			return fmt.Errorf("verify error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// newWriter creates the writer for the generated structs as configured by the
+// settings. Unformatted content only gets indented. Formatted content gets
+// piped through goimports afterwards if configured and found on the PATH. The
+// header, if any, is prepended to the content. Writing to stdout merges the
+// content of all tables like writing a single file.
+func newWriter(s *settings.Settings) output.Writer {
+	decorators := []output.Decorator{
+		output.FormatDecorator{},
+		output.ImportDecorator{},
+	}
+	if s.Formatter == settings.FormatterGoimports {
+		path, err := exec.LookPath("goimports")
+		if err != nil {
+			log := logger.New(s.Quiet, s.Verbose, s.OutputStdout)
+			log.Debugf("> Warning: goimports not found, falling back to gofmt: %v\r\n", err)
+		} else {
+			decorators = append(decorators, output.GoimportsDecorator{Path: path})
+		}
+	}
+	if s.NoFormat {
+		decorators = []output.Decorator{
+			output.IndentDecorator{Indent: s.IndentString()},
+		}
+	}
+	decorators = append(decorators, output.HeaderDecorator{Header: s.HeaderString()})
+
+	if s.OutputStdout {
+		w := output.NewStreamWriter(os.Stdout)
+		w.SetDecorators(decorators...)
+		return w
+	}
+	if s.SingleFile {
+		w := output.NewSingleFileWriter(s.OutputFilePath, s.PackageName)
+		w.SetDecorators(decorators...)
+		w.SetOverwrite(!s.NoOverwrite)
+		w.SetAppend(s.Append)
+		return w
+	}
+	w := output.NewFileWriter(s.OutputFilePath)
+	w.SetDecorators(decorators...)
+	w.SetOverwrite(!s.NoOverwrite)
+	return w
+}
@@ -0,0 +1,225 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/output"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc: "invalid settings produce error before connecting",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.PackageName = ""
+				return s
+			},
+			isError: assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := Generate(test.settings())
+			test.isError(t, err)
+		})
+	}
+}
+
+func TestGenerate_SchemaFile(t *testing.T) {
+	dir := t.TempDir()
+
+	s := settings.New()
+	s.Quiet = true
+	s.OutputFilePath = dir
+	s.SchemaFile = filepath.Join(dir, "schema.json")
+
+	schema := `{"tables": [{"name": "users", "columns": [{"name": "id", "dataType": "integer"}, {"name": "name", "dataType": "text", "nullable": true}]}]}`
+	if err := os.WriteFile(s.SchemaFile, []byte(schema), 0o644); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	if err := Generate(s); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "Users.go"))
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	assert.Contains(t, string(content), "ID   int            `db:\"id\"`")
+	assert.Contains(t, string(content), "Name sql.NullString `db:\"name\"`")
+}
+
+func TestGenerate_Concurrent(t *testing.T) {
+	schema := `{"tables": [{"name": "users", "columns": [{"name": "id", "dataType": "integer"}]}]}`
+
+	newSettings := func(tags func(s *settings.Settings)) *settings.Settings {
+		dir := t.TempDir()
+		s := settings.New()
+		s.Quiet = true
+		s.OutputFilePath = dir
+		s.SchemaFile = filepath.Join(dir, "schema.json")
+		if err := os.WriteFile(s.SchemaFile, []byte(schema), 0o644); err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+		tags(s)
+		return s
+	}
+
+	// each run tags its structs by its own settings, see go test -race
+	gormSettings := newSettings(func(s *settings.Settings) { s.TagsGorm = true })
+	jsonSettings := newSettings(func(s *settings.Settings) { s.TagsJSON = true })
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, s := range []*settings.Settings{gormSettings, jsonSettings} {
+		wg.Add(1)
+		go func(i int, s *settings.Settings) {
+			defer wg.Done()
+			for j := 0; j < 10 && errs[i] == nil; j++ {
+				errs[i] = Generate(s)
+			}
+		}(i, s)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	gormContent, err := os.ReadFile(filepath.Join(gormSettings.OutputFilePath, "Users.go"))
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	assert.Contains(t, string(gormContent), `gorm:"column:id"`)
+	assert.NotContains(t, string(gormContent), `json:"id"`)
+
+	jsonContent, err := os.ReadFile(filepath.Join(jsonSettings.OutputFilePath, "Users.go"))
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	assert.Contains(t, string(jsonContent), `json:"id"`)
+	assert.NotContains(t, string(jsonContent), `gorm:`)
+}
+
+func TestNewWriter(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected output.Writer
+	}{
+		{
+			desc:     "default settings create a file writer",
+			settings: settings.New,
+			expected: &output.FileWriter{},
+		},
+		{
+			desc: "single file creates a single file writer",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.SingleFile = true
+				return s
+			},
+			expected: &output.SingleFileWriter{},
+		},
+		{
+			desc: "output to stdout creates a stream writer",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.OutputStdout = true
+				return s
+			},
+			expected: &output.StreamWriter{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := newWriter(test.settings())
+			assert.IsType(t, test.expected, actual)
+		})
+	}
+}
+
+func TestNewWriter_NoFormat(t *testing.T) {
+	content := "package dto\n\ntype Bar struct {\nID    int `db:\"id\"`\n}"
+
+	tests := []struct {
+		desc     string
+		settings func(s *settings.Settings)
+		expected string
+	}{
+		{
+			desc:     "default settings format the content",
+			settings: func(s *settings.Settings) {},
+			expected: "// Code generated by tables-to-go; DO NOT EDIT.\n\npackage dto\n\ntype Bar struct {\n\tID int `db:\"id\"`\n}\n",
+		},
+		{
+			desc: "custom header gets prepended",
+			settings: func(s *settings.Settings) {
+				s.Header = "Generated from the production database."
+			},
+			expected: "// Generated from the production database.\n\npackage dto\n\ntype Bar struct {\n\tID int `db:\"id\"`\n}\n",
+		},
+		{
+			desc: "empty header gets omitted",
+			settings: func(s *settings.Settings) {
+				s.Header = ""
+			},
+			expected: "package dto\n\ntype Bar struct {\n\tID int `db:\"id\"`\n}\n",
+		},
+		{
+			desc: "goimports not found falls back to gofmt",
+			settings: func(s *settings.Settings) {
+				s.Formatter = settings.FormatterGoimports
+			},
+			expected: "// Code generated by tables-to-go; DO NOT EDIT.\n\npackage dto\n\ntype Bar struct {\n\tID int `db:\"id\"`\n}\n",
+		},
+		{
+			desc: "noformat only indents the content by tabs",
+			settings: func(s *settings.Settings) {
+				s.NoFormat = true
+			},
+			expected: "// Code generated by tables-to-go; DO NOT EDIT.\n\npackage dto\n\ntype Bar struct {\n\tID    int `db:\"id\"`\n}\n",
+		},
+		{
+			desc: "noformat only indents the content by the given indent",
+			settings: func(s *settings.Settings) {
+				s.NoFormat = true
+				s.Indent = "    "
+			},
+			expected: "// Code generated by tables-to-go; DO NOT EDIT.\n\npackage dto\n\ntype Bar struct {\n    ID    int `db:\"id\"`\n}\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			// goimports must not be found
+			t.Setenv("PATH", t.TempDir())
+
+			s := settings.New()
+			s.OutputFilePath = t.TempDir()
+			test.settings(s)
+
+			err := newWriter(s).Write("Bar", content)
+			if err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+
+			actual, err := os.ReadFile(filepath.Join(s.OutputFilePath, "Bar"+output.FileWriterExtension))
+			if err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Logger writes the progress and the errors of the tool. Progress is only
+// written unless quiet, details of the progress only if verbose. Errors are
+// always written.
+type Logger struct {
+	Out     io.Writer
+	Err     io.Writer
+	Quiet   bool
+	Verbose bool
+}
+
+// New creates a new Logger writing the progress to stdout and the errors to
+// stderr. The progress goes to stderr as well if toStderr, eg. to not get
+// mixed into the structs written to stdout.
+func New(quiet bool, verbose bool, toStderr bool) *Logger {
+	out := io.Writer(os.Stdout)
+	if toStderr {
+		out = os.Stderr
+	}
+	return &Logger{
+		Out:     out,
+		Err:     os.Stderr,
+		Quiet:   quiet,
+		Verbose: verbose,
+	}
+}
+
+// Infof writes the progress, unless quiet.
+func (l *Logger) Infof(format string, args ...interface{}) {
+	if l.Quiet {
+		return
+	}
+	fmt.Fprintf(l.Out, format, args...)
+}
+
+// Debugf writes details of the progress, only if verbose and not quiet.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	if !l.Verbose {
+		return
+	}
+	l.Infof(format, args...)
+}
+
+// Errorf writes the error, regardless of quiet.
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	fmt.Fprintf(l.Err, format, args...)
+}
@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger(t *testing.T) {
+	tests := []struct {
+		desc        string
+		quiet       bool
+		verbose     bool
+		expectedOut string
+	}{
+		{
+			desc:        "default writes info",
+			expectedOut: "info\n",
+		},
+		{
+			desc:        "verbose writes info and debug",
+			verbose:     true,
+			expectedOut: "info\ndebug\n",
+		},
+		{
+			desc:        "quiet writes nothing",
+			quiet:       true,
+			expectedOut: "",
+		},
+		{
+			desc:        "quiet takes precedence over verbose",
+			quiet:       true,
+			verbose:     true,
+			expectedOut: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+
+			l := New(test.quiet, test.verbose, false)
+			l.Out = &out
+			l.Err = &errOut
+
+			l.Infof("%s\n", "info")
+			l.Debugf("%s\n", "debug")
+			l.Errorf("%s\n", "error")
+
+			assert.Equal(t, test.expectedOut, out.String())
+			assert.Equal(t, "error\n", errOut.String())
+		})
+	}
+}
+
+func TestNew_ToStderr(t *testing.T) {
+	assert.Equal(t, os.Stdout, New(false, false, false).Out)
+	assert.Equal(t, os.Stderr, New(false, false, true).Out)
+	assert.Equal(t, os.Stderr, New(false, false, true).Err)
+}
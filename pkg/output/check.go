@@ -0,0 +1,164 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CheckError is returned if the go files written could not be compiled. It
+// keeps all errors found.
+type CheckError struct {
+	Errors []error
+}
+
+// Error is the implementation of the error interface.
+func (e *CheckError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d error(s) in generated code:\n%s", len(e.Errors), strings.Join(msgs, "\n"))
+}
+
+// Check parses and type checks the packages of the given files, ie. the go
+// files in the directories of the given files, one package per directory.
+// Other directories, eg. vendor or sub directories, are not checked, nor are
+// the other files of the directories excluded by their build constraints.
+// Imports which cannot be resolved, eg. of modules not downloaded, are not
+// reported as errors; the usages of those packages are not checked then.
+// Nothing gets downloaded to resolve them.
+func Check(fileNames []string) error {
+
+	given := map[string]bool{}
+	dirs := map[string][]string{}
+	for _, fileName := range fileNames {
+		given[filepath.Clean(fileName)] = true
+		dirs[filepath.Dir(fileName)] = nil
+	}
+
+	for dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("could not read directory %q: %w", dir, err)
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || filepath.Ext(name) != FileWriterExtension || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			fileName := filepath.Join(dir, name)
+			// the given files are checked regardless of their build tags
+			if !given[fileName] {
+				if ok, err := build.Default.MatchFile(dir, name); err != nil || !ok {
+					continue
+				}
+			}
+			dirs[dir] = append(dirs[dir], fileName)
+		}
+	}
+
+	dirNames := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		dirNames = append(dirNames, dir)
+	}
+	sort.Strings(dirNames)
+
+	checkErr := &CheckError{}
+	fset := token.NewFileSet()
+
+	for _, dir := range dirNames {
+		checkErr.Errors = append(checkErr.Errors, checkPackage(fset, dir, dirs[dir])...)
+	}
+
+	if len(checkErr.Errors) > 0 {
+		return checkErr
+	}
+
+	return nil
+}
+
+// checkPackage parses and type checks the given files of one package.
+func checkPackage(fset *token.FileSet, dir string, fileNames []string) (errs []error) {
+
+	var files []*ast.File
+
+	for _, fileName := range fileNames {
+		file, err := parser.ParseFile(fset, fileName, nil, parser.AllErrors)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		files = append(files, file)
+	}
+
+	// syntax errors would only lead to follow-up errors of the type checker
+	if len(errs) > 0 {
+		return errs
+	}
+
+	conf := types.Config{
+		Importer: offlineImporter{source: importer.ForCompiler(fset, "source", nil).(types.ImporterFrom)},
+		Error: func(err error) {
+			// continuation errors only point to other declarations
+			var typeErr types.Error
+			if errors.As(err, &typeErr) && (strings.HasPrefix(typeErr.Msg, "could not import") || strings.HasPrefix(typeErr.Msg, "\t")) {
+				return
+			}
+			errs = append(errs, err)
+		},
+	}
+
+	_, _ = conf.Check(dir, fset, files, nil)
+
+	return errs
+}
+
+// offlineImporter imports the packages from their source like the source
+// importer. The packages outside of the standard library must be resolved
+// without the network first, the go command of the source importer would
+// download the modules missing in the go.mod otherwise, eg. in offline CI.
+type offlineImporter struct {
+	source types.ImporterFrom
+}
+
+// Import is the implementation of the types.Importer interface.
+func (imp offlineImporter) Import(path string) (*types.Package, error) {
+	return imp.ImportFrom(path, "", 0)
+}
+
+// ImportFrom is the implementation of the types.ImporterFrom interface.
+func (imp offlineImporter) ImportFrom(path, dir string, mode types.ImportMode) (*types.Package, error) {
+	if !isStdlib(path) && !isResolvableOffline(path, dir) {
+		return nil, fmt.Errorf("package %q cannot be resolved without downloading it", path)
+	}
+	return imp.source.ImportFrom(path, dir, mode)
+}
+
+// isStdlib returns true if the import path is one of the standard library,
+// whose first element has no dot unlike the ones of modules, eg. `net/http`.
+func isStdlib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// isResolvableOffline returns true if the go command finds the directory of
+// the package imported in the given directory without the module proxy. The
+// go.mod is only read, not updated.
+func isResolvableOffline(path, dir string) bool {
+	cmd := exec.Command("go", "list", "-e", "-f", "{{.Dir}}", "--", path)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=readonly")
+	out, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(out)) != ""
+}
@@ -0,0 +1,157 @@
+package output
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		desc           string
+		files          map[string]string
+		written        []string // all files if not given
+		expectedErrors int
+	}{
+		{
+			desc: "valid package produces no error",
+			files: map[string]string{
+				"users.go": "package dto\n\ntype Users struct {\n\tID int `db:\"id\"`\n}\n",
+				"posts.go": "package dto\n\ntype Posts struct {\n\tID     int `db:\"id\"`\n\tAuthor Users\n}\n",
+			},
+		},
+		{
+			desc: "unresolvable imports produce no error",
+			files: map[string]string{
+				"users.go": "package dto\n\nimport (\n\t\"example.com/not/downloaded\"\n)\n\ntype Users struct {\n\tID downloaded.ID `db:\"id\"`\n}\n",
+			},
+		},
+		{
+			desc: "misused standard library produces error",
+			files: map[string]string{
+				"users.go": "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype Users struct {\n\tName sql.NullStr `db:\"name\"`\n}\n",
+			},
+			expectedErrors: 1,
+		},
+		{
+			desc: "syntax error produces error",
+			files: map[string]string{
+				"users.go": "package dto\n\ntype Users struct {\n\tID int `db:\"id\"\n}\n",
+			},
+			expectedErrors: 1,
+		},
+		{
+			desc: "type errors produce errors",
+			files: map[string]string{
+				"users.go": "package dto\n\ntype Users struct {\n\tID Unknown `db:\"id\"`\n}\n",
+				"posts.go": "package dto\n\ntype Users struct{}\n",
+			},
+			expectedErrors: 2,
+		},
+		{
+			desc: "packages in sub directories are checked separately",
+			files: map[string]string{
+				"users.go":       "package dto\n\ntype Users struct{}\n",
+				"audit/users.go": "package dto\n\ntype Users struct{}\n",
+			},
+		},
+		{
+			desc: "other files of the package written into are checked along",
+			files: map[string]string{
+				"users.go":  "package dto\n\ntype Users struct {\n\tStatus Status `db:\"status\"`\n}\n",
+				"status.go": "package dto\n\ntype Status string\n\nvar _ Unknown\n",
+			},
+			written:        []string{"users.go"},
+			expectedErrors: 1,
+		},
+		{
+			desc: "directories not written into are not checked",
+			files: map[string]string{
+				"users.go":          "package dto\n\ntype Users struct{}\n",
+				"vendor/example.go": "package example\n\nvar _ Unknown\n",
+				"testdata/bad.go":   "package bad\n\nfunc {\n",
+			},
+			written: []string{"users.go"},
+		},
+		{
+			desc: "files excluded by their build constraints are not checked",
+			files: map[string]string{
+				"users.go":   "package dto\n\ntype Users struct{}\n",
+				"ignored.go": "//go:build ignore\n\npackage main\n\nvar _ Unknown\n",
+			},
+			written: []string{"users.go"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range test.files {
+				fileName := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+					t.Fatalf("expected non error, got: %s", err)
+				}
+				if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+					t.Fatalf("expected non error, got: %s", err)
+				}
+			}
+
+			written := test.written
+			if written == nil {
+				for name := range test.files {
+					written = append(written, name)
+				}
+			}
+			fileNames := make([]string, 0, len(written))
+			for _, name := range written {
+				fileNames = append(fileNames, filepath.Join(dir, name))
+			}
+
+			err := Check(fileNames)
+			if test.expectedErrors == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var checkErr *CheckError
+			if assert.True(t, errors.As(err, &checkErr)) {
+				assert.Len(t, checkErr.Errors, test.expectedErrors)
+			}
+		})
+	}
+}
+
+func TestCheck_MissingModule(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module example.com/generated\n\ngo 1.18\n",
+		"users.go": "package dto\n\nimport (\n\t\"entgo.io/ent\"\n)\n\ntype Users struct {\n\tent.Schema\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+	}
+
+	// the module missing in the go.mod must not get downloaded
+	done := make(chan error, 1)
+	go func() {
+		done <- Check([]string{filepath.Join(dir, "users.go")})
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(30 * time.Second):
+		t.Fatal("expected check to return without downloading the missing module")
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	assert.Equal(t, files["go.mod"], string(content))
+}
@@ -1,8 +1,10 @@
 package output
 
 import (
+	"bytes"
 	"fmt"
 	"go/format"
+	"os/exec"
 	"strings"
 )
 
@@ -11,6 +13,23 @@ type Decorator interface {
 	Decorate(content string) (string, error)
 }
 
+// FormatError is returned if the content could not be formatted as go source.
+// It keeps the offending source for debugging.
+type FormatError struct {
+	Source string
+	Err    error
+}
+
+// Error is the implementation of the error interface.
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("could not format content: %v", e.Err)
+}
+
+// Unwrap returns the underlying error of go/format.
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
 // FormatDecorator applies a formatting decoration to the given content.
 type FormatDecorator struct{}
 
@@ -18,11 +37,35 @@ type FormatDecorator struct{}
 func (FormatDecorator) Decorate(content string) (string, error) {
 	formatted, err := format.Source([]byte(content))
 	if err != nil {
-		return content, fmt.Errorf("could not format content: %w", err)
+		return content, &FormatError{Source: content, Err: err}
 	}
 	return string(formatted), nil
 }
 
+// GoimportsDecorator pipes the given content through the goimports binary
+// given by its path, which sorts and groups the imports next to formatting.
+type GoimportsDecorator struct {
+	Path string
+}
+
+// Decorate is the implementation of the Decorator interface.
+func (d GoimportsDecorator) Decorate(content string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(d.Path)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return content, &FormatError{Source: content, Err: err}
+	}
+	return stdout.String(), nil
+}
+
 // ImportDecorator removes empty import statements from the given content.
 type ImportDecorator struct{}
 
@@ -32,3 +75,65 @@ func (ImportDecorator) Decorate(content string) (string, error) {
 	decorated := strings.ReplaceAll(content, "\nimport ()\n", "")
 	return decorated, nil
 }
+
+// IndentDecorator indents the lines of the given content by the depth of the
+// blocks they are in. It is meant for unformatted content, formatted content
+// is already indented by go/format.
+type IndentDecorator struct {
+	Indent string
+}
+
+// Decorate is the implementation of the Decorator interface.
+func (d IndentDecorator) Decorate(content string) (string, error) {
+	var (
+		decorated strings.Builder
+		depth     int
+	)
+
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		line = strings.TrimSpace(line)
+
+		if (strings.HasPrefix(line, "}") || strings.HasPrefix(line, ")")) && depth > 0 {
+			depth--
+		}
+		if line != "" {
+			decorated.WriteString(strings.Repeat(d.Indent, depth))
+			decorated.WriteString(line)
+		}
+		decorated.WriteString("\n")
+
+		if strings.HasSuffix(line, "{") || strings.HasSuffix(line, "(") {
+			depth++
+		}
+	}
+
+	return decorated.String(), nil
+}
+
+// HeaderDecorator prepends the given header as line comments to the content,
+// eg. to mark the content as generated code. Lines of the header which are
+// already comments are kept as they are.
+type HeaderDecorator struct {
+	Header string
+}
+
+// Decorate is the implementation of the Decorator interface.
+func (d HeaderDecorator) Decorate(content string) (string, error) {
+	if d.Header == "" {
+		return content, nil
+	}
+
+	var decorated strings.Builder
+
+	for _, line := range strings.Split(strings.TrimRight(d.Header, "\n"), "\n") {
+		if !strings.HasPrefix(line, "//") {
+			line = "// " + line
+		}
+		decorated.WriteString(line)
+		decorated.WriteString("\n")
+	}
+	decorated.WriteString("\n")
+	decorated.WriteString(content)
+
+	return decorated.String(), nil
+}
@@ -1,6 +1,9 @@
 package output
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,6 +40,10 @@ type Bar struct {
 			actual, err := decorator.Decorate(test.input)
 			if err != nil {
 				test.isError(t, err)
+				var formatErr *FormatError
+				if assert.ErrorAs(t, err, &formatErr) {
+					assert.Equal(t, test.input, formatErr.Source)
+				}
 				return
 			}
 			assert.Equal(t, test.expected, actual)
@@ -44,6 +51,68 @@ type Bar struct {
 	}
 }
 
+func TestGoimportsDecorator_Decorate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on windows")
+	}
+
+	// the scripts stand in for the goimports binary
+	dir := t.TempDir()
+	scripts := map[string]string{
+		"upper": "#!/bin/sh\ntr a-z A-Z\n",
+		"fail":  "#!/bin/sh\necho 'expected declaration' >&2\nexit 2\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		path     string
+		input    string
+		expected string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "content gets piped through the binary",
+			path:     filepath.Join(dir, "upper"),
+			input:    "package dto\n",
+			expected: "PACKAGE DTO\n",
+			isError:  assert.NoError,
+		},
+		{
+			desc:    "failing binary throws error with its output",
+			path:    filepath.Join(dir, "fail"),
+			input:   "package dto\n",
+			isError: assert.Error,
+		},
+		{
+			desc:    "missing binary throws error",
+			path:    filepath.Join(dir, "missing"),
+			input:   "package dto\n",
+			isError: assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			decorator := GoimportsDecorator{Path: test.path}
+			actual, err := decorator.Decorate(test.input)
+			if err != nil {
+				test.isError(t, err)
+				var formatErr *FormatError
+				if assert.ErrorAs(t, err, &formatErr) {
+					assert.Equal(t, test.input, formatErr.Source)
+				}
+				return
+			}
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestImportDecorator_Decorate(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -76,3 +145,75 @@ func TestImportDecorator_Decorate(t *testing.T) {
 		})
 	}
 }
+
+func TestIndentDecorator_Decorate(t *testing.T) {
+	tests := []struct {
+		desc     string
+		indent   string
+		input    string
+		expected string
+	}{
+		{
+			desc:     "lines in blocks get indented by tabs",
+			indent:   "\t",
+			input:    "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype Bar struct {\nID int `db:\"id\"`\nName sql.NullString `db:\"name\"`\n}",
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype Bar struct {\n\tID int `db:\"id\"`\n\tName sql.NullString `db:\"name\"`\n}\n",
+		},
+		{
+			desc:     "lines in nested blocks get indented by spaces",
+			indent:   "  ",
+			input:    "package dto\n\nfunc (Bar) TableName() string {\nreturn \"bar\"\n}\n\nconst (\nA = \"a\"\n)",
+			expected: "package dto\n\nfunc (Bar) TableName() string {\n  return \"bar\"\n}\n\nconst (\n  A = \"a\"\n)\n",
+		},
+		{
+			desc:     "empty lines in blocks do not get indented",
+			indent:   "\t",
+			input:    "package dto\n\ntype Bar struct {\nID int `db:\"id\"`\n\t\nstructable.Recorder\n}",
+			expected: "package dto\n\ntype Bar struct {\n\tID int `db:\"id\"`\n\n\tstructable.Recorder\n}\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			decorator := IndentDecorator{Indent: test.indent}
+			actual, err := decorator.Decorate(test.input)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestHeaderDecorator_Decorate(t *testing.T) {
+	tests := []struct {
+		desc     string
+		header   string
+		input    string
+		expected string
+	}{
+		{
+			desc:     "header gets prepended as comment",
+			header:   "Code generated by tables-to-go; DO NOT EDIT.",
+			input:    "package dto\n",
+			expected: "// Code generated by tables-to-go; DO NOT EDIT.\n\npackage dto\n",
+		},
+		{
+			desc:     "multi line header keeps existing comments",
+			header:   "// Copyright\nCode generated.\n",
+			input:    "package dto\n",
+			expected: "// Copyright\n// Code generated.\n\npackage dto\n",
+		},
+		{
+			desc:     "empty header keeps the content",
+			header:   "",
+			input:    "package dto\n",
+			expected: "package dto\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			decorator := HeaderDecorator{Header: test.header}
+			actual, err := decorator.Decorate(test.input)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
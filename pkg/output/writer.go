@@ -1,8 +1,18 @@
 package output
 
 import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
 	"os"
-	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 )
 
 const (
@@ -10,15 +20,35 @@ const (
 	FileWriterExtension = ".go"
 )
 
+// ErrFileExists is returned if the file to write already exists and must not
+// be overwritten.
+var ErrFileExists = errors.New("file already exists")
+
 // Writer represents an interface to write the produced struct content.
 type Writer interface {
 	Write(tableName string, content string) error
 }
 
+// Flusher represents an interface for writers which buffer the produced
+// content and write it at once after all tables were processed.
+type Flusher interface {
+	Flush() error
+}
+
+// Recorder represents an interface for writers which keep the names of the
+// files they have written, eg. to check them afterwards.
+type Recorder interface {
+	Files() []string
+}
+
 // FileWriter is a writer that writes to a file given by the path and the table name.
 type FileWriter struct {
 	path       string
 	decorators []Decorator
+	overwrite  bool
+
+	mu    sync.Mutex
+	files []string
 }
 
 // NewFileWriter constructs a new FileWriter.
@@ -29,25 +59,503 @@ func NewFileWriter(path string) *FileWriter {
 			FormatDecorator{},
 			ImportDecorator{},
 		},
+		overwrite: true,
 	}
 }
 
+// SetDecorators replaces the decorators applied to the content before it gets
+// written.
+func (w *FileWriter) SetDecorators(decorators ...Decorator) {
+	w.decorators = decorators
+}
+
+// SetOverwrite sets whether existing files get overwritten, otherwise writing
+// them fails with ErrFileExists.
+func (w *FileWriter) SetOverwrite(overwrite bool) {
+	w.overwrite = overwrite
+}
+
 // Write is the implementation of the Writer interface. The FilerWriter writes
 // decorated content to the file specified by the given path and table name.
-func (w FileWriter) Write(tableName string, content string) error {
-	fileName := path.Join(w.path, tableName+FileWriterExtension)
+// The table name may contain sub directories separated by slashes, they get
+// created if needed.
+func (w *FileWriter) Write(tableName string, content string) error {
+	fileName := filepath.Join(w.path, filepath.FromSlash(tableName)+FileWriterExtension)
 
-	decorated, err := w.decorate(content)
+	decorated, err := decorate(content, w.decorators)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(fileName, []byte(decorated), 0666)
+	if err = os.MkdirAll(filepath.Dir(fileName), 0777); err != nil {
+		return fmt.Errorf("could not create directory of file %q: %w", fileName, err)
+	}
+
+	if err = writeFile(fileName, decorated, w.overwrite); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.files = append(w.files, fileName)
+
+	return nil
 }
 
-// decorate applies some decorations like formatting and empty import removal.
-func (w FileWriter) decorate(content string) (decorated string, err error) {
+// Files is the implementation of the Recorder interface.
+func (w *FileWriter) Files() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.files...)
+}
+
+// SingleFileWriter is a writer that collects the content of all tables and
+// writes it merged into one single file given by the path and the file name.
+type SingleFileWriter struct {
+	path       string
+	fileName   string
+	decorators []Decorator
+	overwrite  bool
+	append     bool
+
+	contents map[string]string
+	files    []string
+}
+
+// NewSingleFileWriter constructs a new SingleFileWriter.
+func NewSingleFileWriter(path string, fileName string) *SingleFileWriter {
+	return &SingleFileWriter{
+		path:     path,
+		fileName: fileName,
+		decorators: []Decorator{
+			FormatDecorator{},
+			ImportDecorator{},
+		},
+		overwrite: true,
+		contents:  map[string]string{},
+	}
+}
+
+// SetDecorators replaces the decorators applied to the merged content before
+// it gets written.
+func (w *SingleFileWriter) SetDecorators(decorators ...Decorator) {
+	w.decorators = decorators
+}
+
+// SetOverwrite sets whether an existing file gets overwritten, otherwise
+// flushing fails with ErrFileExists.
+func (w *SingleFileWriter) SetOverwrite(overwrite bool) {
+	w.overwrite = overwrite
+}
+
+// SetAppend sets whether the contents get appended to an existing file instead
+// of overwriting it. Contents declaring a type the file declares already are
+// skipped, eg. to keep hand-extended structs.
+func (w *SingleFileWriter) SetAppend(appending bool) {
+	w.append = appending
+}
+
+// Write is the implementation of the Writer interface. The SingleFileWriter
+// only buffers the content, it gets written by Flush. The content is formatted
+// once to report invalid content for its table instead of for the whole file.
+func (w *SingleFileWriter) Write(tableName string, content string) error {
+	if _, err := (FormatDecorator{}).Decorate(content); err != nil {
+		return err
+	}
+	w.contents[tableName] = content
+	return nil
+}
+
+// Flush is the implementation of the Flusher interface. It merges the buffered
+// contents into one file with a single package clause and one de-duplicated
+// import block and writes the decorated result to the file.
+func (w *SingleFileWriter) Flush() error {
+	if len(w.contents) == 0 {
+		return nil
+	}
+
+	fileName := filepath.Join(w.path, w.fileName+FileWriterExtension)
+
+	if w.append {
+		existing, err := os.ReadFile(fileName)
+		if err == nil {
+			return w.appendTo(fileName, string(existing))
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("could not read file to append to: %w", err)
+		}
+	}
+
+	decorated, err := w.merge()
+	if err != nil {
+		return err
+	}
+
+	if err = writeFile(fileName, decorated, w.overwrite); err != nil {
+		return err
+	}
+	w.files = append(w.files, fileName)
+
+	return nil
+}
+
+// Files is the implementation of the Recorder interface.
+func (w *SingleFileWriter) Files() []string {
+	return w.files
+}
+
+// appendTo appends the buffered contents to the existing content of the file
+// and writes the decorated result to it. The file is left untouched if there
+// is nothing new to append. The header is not decorated again, the existing
+// content has it already.
+func (w *SingleFileWriter) appendTo(fileName string, existing string) error {
+
+	appended, ok, err := appendContents(existing, w.sortedContents())
+	if err != nil {
+		return fmt.Errorf("could not append to file %q: %w", fileName, err)
+	}
+	if !ok {
+		return nil
+	}
+
+	decorators := make([]Decorator, 0, len(w.decorators))
 	for _, decorator := range w.decorators {
+		if _, ok := decorator.(HeaderDecorator); ok {
+			continue
+		}
+		decorators = append(decorators, decorator)
+	}
+
+	decorated, err := decorate(appended, decorators)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(fileName, []byte(decorated), 0666); err != nil {
+		return err
+	}
+	w.files = append(w.files, fileName)
+
+	return nil
+}
+
+// sortedContents returns the buffered contents in the order of their table
+// names.
+func (w *SingleFileWriter) sortedContents() []string {
+
+	tableNames := make([]string, 0, len(w.contents))
+	for tableName := range w.contents {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+
+	contents := make([]string, 0, len(tableNames))
+	for _, tableName := range tableNames {
+		contents = append(contents, w.contents[tableName])
+	}
+
+	return contents
+}
+
+// merge merges the buffered contents in the order of their table names and
+// decorates the result.
+func (w *SingleFileWriter) merge() (string, error) {
+
+	merged, err := mergeContents(w.sortedContents())
+	if err != nil {
+		return merged, err
+	}
+
+	return decorate(merged, w.decorators)
+}
+
+// StreamWriter is a writer that collects the content of all tables like the
+// SingleFileWriter but writes it merged into the given stream, eg. stdout.
+type StreamWriter struct {
+	*SingleFileWriter
+	out io.Writer
+}
+
+// NewStreamWriter constructs a new StreamWriter.
+func NewStreamWriter(out io.Writer) *StreamWriter {
+	return &StreamWriter{
+		SingleFileWriter: NewSingleFileWriter("", ""),
+		out:              out,
+	}
+}
+
+// Flush is the implementation of the Flusher interface. It merges the buffered
+// contents like the SingleFileWriter and writes the decorated result to the
+// stream.
+func (w *StreamWriter) Flush() error {
+	if len(w.contents) == 0 {
+		return nil
+	}
+
+	decorated, err := w.merge()
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w.out, decorated)
+	return err
+}
+
+// writeFile writes the content to the file. If the file must not be
+// overwritten, it is created exclusively to not race with other writers.
+func writeFile(fileName string, content string, overwrite bool) error {
+	if overwrite {
+		return os.WriteFile(fileName, []byte(content), 0666)
+	}
+
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%w: %s", ErrFileExists, fileName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.WriteString(content); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// mergeContents merges the given contents of go files into the content of
+// one go file. Everything in front of the package clause, as well as the
+// package name, is taken from the first content. The imports of all contents
+// are merged into one import block, all other declarations are kept as they are.
+func mergeContents(contents []string) (string, error) {
+	var (
+		header      string
+		packageName string
+		imports     []string
+		decls       []string
+	)
+
+	seenImports := map[string]struct{}{}
+	fset := token.NewFileSet()
+
+	for i, content := range contents {
+		file, err := parser.ParseFile(fset, "", content, parser.ParseComments)
+		if err != nil {
+			return content, fmt.Errorf("could not parse content: %w", err)
+		}
+
+		if i == 0 {
+			header = content[:fset.Position(file.Package).Offset]
+			packageName = file.Name.Name
+		}
+
+		fileImports, fileDecls := splitDecls(fset, file, content)
+		for _, imp := range fileImports {
+			if _, ok := seenImports[imp]; ok {
+				continue
+			}
+			seenImports[imp] = struct{}{}
+			imports = append(imports, imp)
+		}
+		decls = append(decls, fileDecls...)
+	}
+
+	var merged strings.Builder
+
+	merged.WriteString(header)
+	merged.WriteString("package ")
+	merged.WriteString(packageName)
+	merged.WriteString("\n\n")
+
+	if len(imports) > 0 {
+		merged.WriteString("import (\n")
+		for _, imp := range imports {
+			merged.WriteString("\t")
+			merged.WriteString(imp)
+			merged.WriteString("\n")
+		}
+		merged.WriteString(")\n\n")
+	}
+
+	merged.WriteString(strings.Join(decls, "\n\n"))
+	merged.WriteString("\n")
+
+	return merged.String(), nil
+}
+
+// appendContents appends the declarations of the given contents of go files to
+// the existing content of a go file, which is kept as it is. Contents declaring
+// a type the existing content declares already are skipped as a whole, along
+// with their methods. Missing imports are added to the last import declaration
+// of the existing content. It reports whether anything was appended.
+func appendContents(existing string, contents []string) (string, bool, error) {
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "", existing, parser.ParseComments)
+	if err != nil {
+		return existing, false, fmt.Errorf("could not parse existing content: %w", err)
+	}
+
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	seenTypes := map[string]struct{}{}
+	for _, name := range typeNames(file) {
+		seenTypes[name] = struct{}{}
+	}
+
+	seenImports := map[string]struct{}{}
+	existingImports, _ := splitDecls(fset, file, existing)
+	for _, imp := range existingImports {
+		seenImports[imp] = struct{}{}
+	}
+
+	var (
+		imports []string
+		decls   []string
+	)
+
+	for _, content := range contents {
+		contentFset := token.NewFileSet()
+		contentFile, err := parser.ParseFile(contentFset, "", content, parser.ParseComments)
+		if err != nil {
+			return content, false, fmt.Errorf("could not parse content: %w", err)
+		}
+
+		names := typeNames(contentFile)
+		if isAnyDeclared(names, seenTypes) {
+			continue
+		}
+		for _, name := range names {
+			seenTypes[name] = struct{}{}
+		}
+
+		contentImports, contentDecls := splitDecls(contentFset, contentFile, content)
+		for _, imp := range contentImports {
+			if _, ok := seenImports[imp]; ok {
+				continue
+			}
+			seenImports[imp] = struct{}{}
+			imports = append(imports, imp)
+		}
+		decls = append(decls, contentDecls...)
+	}
+
+	if len(decls) == 0 {
+		return existing, false, nil
+	}
+
+	appended := existing
+
+	if len(imports) > 0 {
+		var lastImport *ast.GenDecl
+		for _, decl := range file.Decls {
+			if d, ok := decl.(*ast.GenDecl); ok && d.Tok == token.IMPORT {
+				lastImport = d
+			}
+		}
+
+		var (
+			at    int
+			block strings.Builder
+		)
+		switch {
+		case lastImport != nil && lastImport.Lparen.IsValid():
+			at = offset(lastImport.Rparen)
+			for _, imp := range imports {
+				block.WriteString("\t")
+				block.WriteString(imp)
+				block.WriteString("\n")
+			}
+		default:
+			at = offset(file.Name.End())
+			if lastImport != nil {
+				at = offset(lastImport.End())
+			}
+			block.WriteString("\n\nimport (\n")
+			for _, imp := range imports {
+				block.WriteString("\t")
+				block.WriteString(imp)
+				block.WriteString("\n")
+			}
+			block.WriteString(")")
+		}
+
+		appended = appended[:at] + block.String() + appended[at:]
+	}
+
+	appended = strings.TrimRight(appended, "\n") + "\n\n" + strings.Join(decls, "\n\n") + "\n"
+
+	return appended, true, nil
+}
+
+// splitDecls splits the declarations of the parsed file into its import specs
+// and the source of all other declarations along with their doc comments.
+func splitDecls(fset *token.FileSet, file *ast.File, content string) (imports []string, decls []string) {
+
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	for _, decl := range file.Decls {
+		start := decl.Pos()
+
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				for _, spec := range d.Specs {
+					imports = append(imports, content[offset(spec.Pos()):offset(spec.End())])
+				}
+				continue
+			}
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		}
+
+		decls = append(decls, content[offset(start):offset(decl.End())])
+	}
+
+	return imports, decls
+}
+
+// typeNames returns the names of the types declared by the parsed file.
+func typeNames(file *ast.File) []string {
+	var names []string
+	for _, decl := range file.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok || d.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range d.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok {
+				names = append(names, ts.Name.Name)
+			}
+		}
+	}
+	return names
+}
+
+// isAnyDeclared reports whether any of the names is declared already.
+func isAnyDeclared(names []string, declared map[string]struct{}) bool {
+	for _, name := range names {
+		if _, ok := declared[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// decorate applies some decorations like formatting and empty import removal.
+func decorate(content string, decorators []Decorator) (decorated string, err error) {
+	for _, decorator := range decorators {
 		content, err = decorator.Decorate(content)
 		if err != nil {
 			return content, err
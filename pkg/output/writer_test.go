@@ -1,8 +1,11 @@
 package output
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,3 +58,308 @@ func TestFileWriter_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestFileWriter_WriteSubDir(t *testing.T) {
+	dir := t.TempDir()
+
+	err := NewFileWriter(dir).Write("public/Bar", "package dto\ntype Bar struct {\nID int `db:\"id\"`\n}")
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	_, err = os.Stat(path.Join(dir, "public", "Bar"+FileWriterExtension))
+	assert.NoError(t, err)
+}
+
+func TestFileWriter_WriteInsidePath(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "output")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	// the path does not end with a separator
+	err := NewFileWriter(dir).Write("Bar", "package dto\ntype Bar struct {\nID int `db:\"id\"`\n}")
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "Bar"+FileWriterExtension))
+	assert.NoError(t, err)
+
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "output", entries[0].Name())
+	}
+}
+
+func TestFileWriter_WriteNoOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	content := "package dto\ntype Bar struct {\nID int `db:\"id\"`\n}"
+
+	w := NewFileWriter(dir)
+	w.SetOverwrite(false)
+
+	err := w.Write("Bar", content)
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	err = w.Write("Bar", content)
+	assert.True(t, errors.Is(err, ErrFileExists))
+}
+
+func TestFileWriter_Files(t *testing.T) {
+	dir := t.TempDir()
+	content := "package dto\ntype Bar struct {\nID int `db:\"id\"`\n}"
+
+	w := NewFileWriter(dir)
+	w.SetOverwrite(false)
+
+	assert.NoError(t, w.Write("Bar", content))
+	assert.NoError(t, w.Write("public/Bar", content))
+	assert.Error(t, w.Write("Bar", content))
+	assert.Error(t, w.Write("Foo", "Lorem ipsum"))
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "Bar"+FileWriterExtension),
+		filepath.Join(dir, "public", "Bar"+FileWriterExtension),
+	}, w.Files())
+}
+
+func TestSingleFileWriter_Flush(t *testing.T) {
+	tests := []struct {
+		desc     string
+		contents map[string]string
+		expected string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc: "contents without imports get merged into one file",
+			contents: map[string]string{
+				"Foo": "package dto\n\ntype Foo struct {\nID int `db:\"id\"`\n}",
+				"Bar": "package dto\n\ntype Bar struct {\nID int `db:\"id\"`\n}",
+			},
+			expected: "package dto\n\ntype Bar struct {\n\tID int `db:\"id\"`\n}\n\ntype Foo struct {\n\tID int `db:\"id\"`\n}\n",
+			isError:  assert.NoError,
+		},
+		{
+			desc: "different combinations of imports get merged into one de-duplicated import block",
+			contents: map[string]string{
+				"A": "package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype A struct {\nName sql.NullString `db:\"name\"`\nCreatedAt time.Time `db:\"created_at\"`\n}",
+				"B": "package dto\n\nimport (\n\t\"time\"\n)\n\ntype B struct {\nCreatedAt time.Time `db:\"created_at\"`\n}",
+				"C": "package dto\n\nimport (\n\t\"github.com/lib/pq\"\n\t\"database/sql\"\n)\n\ntype C struct {\nDeletedAt pq.NullTime `db:\"deleted_at\"`\nName sql.NullString `db:\"name\"`\n}",
+				"D": "package dto\n\ntype D struct {\nID int `db:\"id\"`\n}",
+			},
+			expected: `package dto
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"time"
+)
+
+type A struct {
+	Name      sql.NullString ` + "`db:\"name\"`" + `
+	CreatedAt time.Time      ` + "`db:\"created_at\"`" + `
+}
+
+type B struct {
+	CreatedAt time.Time ` + "`db:\"created_at\"`" + `
+}
+
+type C struct {
+	DeletedAt pq.NullTime    ` + "`db:\"deleted_at\"`" + `
+	Name      sql.NullString ` + "`db:\"name\"`" + `
+}
+
+type D struct {
+	ID int ` + "`db:\"id\"`" + `
+}
+`,
+			isError: assert.NoError,
+		},
+		{
+			desc: "invalid content should produce an error on write",
+			contents: map[string]string{
+				"Bar": "Lorem ipsum dolor sit amet, consectetur adipiscing elit",
+			},
+			isError: assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			dir := t.TempDir()
+
+			fw := NewSingleFileWriter(dir, "dto")
+			for tableName, content := range test.contents {
+				if err := fw.Write(tableName, content); err != nil {
+					test.isError(t, err)
+					return
+				}
+			}
+
+			err := fw.Flush()
+			if err != nil {
+				test.isError(t, err)
+				return
+			}
+
+			actual, err := os.ReadFile(path.Join(dir, "dto"+FileWriterExtension))
+			if err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestStreamWriter_Flush(t *testing.T) {
+	var out bytes.Buffer
+
+	sw := NewStreamWriter(&out)
+	for tableName, content := range map[string]string{
+		"Foo": "package dto\n\nimport (\n\t\"time\"\n)\n\ntype Foo struct {\nCreatedAt time.Time `db:\"created_at\"`\n}",
+		"Bar": "package dto\n\nimport (\n\t\"time\"\n)\n\ntype Bar struct {\nCreatedAt time.Time `db:\"created_at\"`\n}",
+	} {
+		if err := sw.Write(tableName, content); err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+	}
+
+	assert.Empty(t, out.String(), "nothing written before flush")
+
+	if err := sw.Flush(); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	expected := "package dto\n\nimport (\n\t\"time\"\n)\n\n" +
+		"type Bar struct {\n\tCreatedAt time.Time `db:\"created_at\"`\n}\n\n" +
+		"type Foo struct {\n\tCreatedAt time.Time `db:\"created_at\"`\n}\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestSingleFileWriter_FlushAppend(t *testing.T) {
+	tests := []struct {
+		desc     string
+		existing string
+		contents map[string]string
+		expected string
+	}{
+		{
+			desc: "new structs get appended and their imports added to the import block of the file",
+			existing: `package dto
+
+import (
+	"time"
+)
+
+type Foo struct {
+	CreatedAt time.Time ` + "`db:\"created_at\"`" + `
+}
+
+// IsNew is hand-written.
+func (f Foo) IsNew() bool {
+	return time.Since(f.CreatedAt) < time.Hour
+}
+`,
+			contents: map[string]string{
+				"Foo": "package dto\n\ntype Foo struct {\nID int `db:\"id\"`\n}",
+				"Bar": "package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype Bar struct {\nName sql.NullString `db:\"name\"`\nCreatedAt time.Time `db:\"created_at\"`\n}",
+			},
+			expected: `package dto
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Foo struct {
+	CreatedAt time.Time ` + "`db:\"created_at\"`" + `
+}
+
+// IsNew is hand-written.
+func (f Foo) IsNew() bool {
+	return time.Since(f.CreatedAt) < time.Hour
+}
+
+type Bar struct {
+	Name      sql.NullString ` + "`db:\"name\"`" + `
+	CreatedAt time.Time      ` + "`db:\"created_at\"`" + `
+}
+`,
+		},
+		{
+			desc:     "file without imports gets an import block and keeps its header",
+			existing: "// Code generated by tables-to-go. DO NOT EDIT.\n\npackage dto\n\ntype Foo struct {\n\tID int `db:\"id\"`\n}\n",
+			contents: map[string]string{
+				"Bar": "package dto\n\nimport (\n\t\"time\"\n)\n\ntype Bar struct {\nCreatedAt time.Time `db:\"created_at\"`\n}",
+			},
+			expected: "// Code generated by tables-to-go. DO NOT EDIT.\n\npackage dto\n\nimport (\n\t\"time\"\n)\n\n" +
+				"type Foo struct {\n\tID int `db:\"id\"`\n}\n\n" +
+				"type Bar struct {\n\tCreatedAt time.Time `db:\"created_at\"`\n}\n",
+		},
+		{
+			desc:     "file declaring all structs already is left untouched",
+			existing: "package dto\n\ntype Foo struct {\n\tID   int `db:\"id\"`\n\n\n}\n",
+			contents: map[string]string{
+				"Foo": "package dto\n\ntype Foo struct {\nID int `db:\"id\"`\n}",
+			},
+			expected: "package dto\n\ntype Foo struct {\n\tID   int `db:\"id\"`\n\n\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			fileName := path.Join(dir, "dto"+FileWriterExtension)
+
+			if err := os.WriteFile(fileName, []byte(test.existing), 0666); err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+
+			fw := NewSingleFileWriter(dir, "dto")
+			fw.SetDecorators(FormatDecorator{}, ImportDecorator{}, HeaderDecorator{Header: "Code generated by tables-to-go. DO NOT EDIT."})
+			fw.SetAppend(true)
+			for tableName, content := range test.contents {
+				if err := fw.Write(tableName, content); err != nil {
+					t.Fatalf("expected non error, got: %s", err)
+				}
+			}
+
+			if err := fw.Flush(); err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+
+			actual, err := os.ReadFile(fileName)
+			if err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestSingleFileWriter_FlushAppendNoFile(t *testing.T) {
+	dir := t.TempDir()
+
+	fw := NewSingleFileWriter(dir, "dto")
+	fw.SetAppend(true)
+	if err := fw.Write("Foo", "package dto\n\ntype Foo struct {\nID int `db:\"id\"`\n}"); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	if err := fw.Flush(); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	actual, err := os.ReadFile(path.Join(dir, "dto"+FileWriterExtension))
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	assert.Equal(t, "package dto\n\ntype Foo struct {\n\tID int `db:\"id\"`\n}\n", string(actual))
+}
@@ -1,9 +1,23 @@
 package settings
 
 import (
+	"errors"
 	"fmt"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
 )
 
 // DBType represents a type of a database.
@@ -14,6 +28,8 @@ const (
 	DBTypePostgresql DBType = "pg"
 	DBTypeMySQL      DBType = "mysql"
 	DBTypeSQLite     DBType = "sqlite3"
+	DBTypeCockroach  DBType = "cockroach"
+	DBTypeRedshift   DBType = "redshift"
 )
 
 // Set sets the datatype for the custom type for the flag package.
@@ -34,12 +50,23 @@ func (db DBType) String() string {
 	return string(db)
 }
 
-// These null types are supported. The types native and primitive map to the same
-// underlying builtin golang type.
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file.
+func (db *DBType) UnmarshalText(text []byte) error {
+	return db.Set(string(text))
+}
+
+// These null types are supported. The types native, primitive and pointer(s)
+// map to the same underlying builtin golang type. The type zero maps to the
+// builtin golang type itself along with a boolean field telling if the value
+// is valid, ie. not NULL.
 const (
 	NullTypeSQL       NullType = "sql"
 	NullTypeNative    NullType = "native"
 	NullTypePrimitive NullType = "primitive"
+	NullTypePointers  NullType = "pointers"
+	NullTypePointer   NullType = "pointer"
+	NullTypeZero      NullType = "zero"
 )
 
 // NullType represents a null type.
@@ -64,6 +91,12 @@ func (t NullType) String() string {
 	return string(t)
 }
 
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file.
+func (t *NullType) UnmarshalText(text []byte) error {
+	return t.Set(string(text))
+}
+
 // OutputFormat represents an output format option.
 type OutputFormat string
 
@@ -91,6 +124,12 @@ func (of OutputFormat) String() string {
 	return string(of)
 }
 
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file.
+func (of *OutputFormat) UnmarshalText(text []byte) error {
+	return of.Set(string(text))
+}
+
 // FileNameFormat represents a output filename format.
 type FileNameFormat string
 
@@ -98,11 +137,16 @@ type FileNameFormat string
 const (
 	FileNameFormatCamelCase FileNameFormat = "c"
 	FileNameFormatSnakeCase FileNameFormat = "s"
+	FileNameFormatOriginal  FileNameFormat = "o"
 )
 
-// Set sets the datatype for the custom type for the flag package.
+// Set sets the datatype for the custom type for the flag package. Next to the
+// short forms, the long forms camel, snake and original are supported.
 func (of *FileNameFormat) Set(s string) error {
 	*of = FileNameFormat(s)
+	if format, ok := fileNameFormatLongForms[s]; ok {
+		*of = format
+	}
 	if *of == "" {
 		*of = FileNameFormatCamelCase
 	}
@@ -116,12 +160,220 @@ func (of FileNameFormat) String() string {
 	return string(of)
 }
 
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file.
+func (of *FileNameFormat) UnmarshalText(text []byte) error {
+	return of.Set(string(text))
+}
+
+// TableConst represents how the name of the table gets generated along with
+// its struct.
+type TableConst string
+
+// These are the TableConst command line parameter.
+const (
+	TableConstNone   TableConst = ""
+	TableConstMethod TableConst = "method"
+	TableConstConst  TableConst = "const"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (tc *TableConst) Set(s string) error {
+	*tc = TableConst(s)
+	if !supportedTableConsts[*tc] {
+		return fmt.Errorf("table const %q not supported, must be one of: method, const", *tc)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (tc TableConst) String() string {
+	return string(tc)
+}
+
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file.
+func (tc *TableConst) UnmarshalText(text []byte) error {
+	return tc.Set(string(text))
+}
+
+// List represents a comma separated list of values.
+type List []string
+
+// Set sets the values of the comma separated list for the flag package.
+func (l *List) Set(s string) error {
+	*l = nil
+	for _, value := range strings.Split(s, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		*l = append(*l, value)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (l List) String() string {
+	return strings.Join(l, ",")
+}
+
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file. Next to a comma
+// separated list, the config file supports a sequence of values as well.
+func (l *List) UnmarshalText(text []byte) error {
+	return l.Set(string(text))
+}
+
+// Map represents a comma separated list of key:value pairs.
+type Map map[string]string
+
+// Set sets the key:value pairs of the comma separated list for the flag
+// package.
+func (m *Map) Set(s string) error {
+	*m = Map{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" || strings.TrimSpace(kv[1]) == "" {
+			return fmt.Errorf("pair %q not supported, must be of form key:value", pair)
+		}
+		(*m)[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (m Map) String() string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+":"+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file. Next to a comma
+// separated list, the config file supports a mapping as well.
+func (m *Map) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
+
+// SSLMode represents the mode of the SSL/TLS connection to the database.
+type SSLMode string
+
+// These are the SSLMode command line parameter.
+const (
+	SSLModeDisable    SSLMode = "disable"
+	SSLModeRequire    SSLMode = "require"
+	SSLModeVerifyCA   SSLMode = "verify-ca"
+	SSLModeVerifyFull SSLMode = "verify-full"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (m *SSLMode) Set(s string) error {
+	*m = SSLMode(s)
+	if *m == "" {
+		*m = SSLModeDisable
+	}
+	if !supportedSSLModes[*m] {
+		return fmt.Errorf("ssl mode %q not supported, must be one of: %v",
+			*m, SprintfSupportedSSLModes())
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (m SSLMode) String() string {
+	return string(m)
+}
+
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file.
+func (m *SSLMode) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
+
+// Formatter represents the tool formatting the generated code.
+type Formatter string
+
+// These are the Formatter command line parameter.
+const (
+	FormatterGofmt     Formatter = "gofmt"
+	FormatterGoimports Formatter = "goimports"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (f *Formatter) Set(s string) error {
+	*f = Formatter(s)
+	if *f == "" {
+		*f = FormatterGofmt
+	}
+	if !supportedFormatters[*f] {
+		return fmt.Errorf("formatter %q not supported, must be one of: %v",
+			*f, SprintfSupportedFormatters())
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (f Formatter) String() string {
+	return string(f)
+}
+
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file.
+func (f *Formatter) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
+}
+
+// Timestamp represents a point in time given in the format of RFC 3339, eg.
+// 2024-01-02T15:04:05Z. The zero value means no point in time.
+type Timestamp struct {
+	time.Time
+}
+
+// Set sets the datatype for the custom type for the flag package.
+func (ts *Timestamp) Set(s string) error {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("timestamp %q not supported, must be in the format of RFC 3339, eg. 2024-01-02T15:04:05Z", s)
+	}
+	ts.Time = t
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (ts Timestamp) String() string {
+	if ts.IsZero() {
+		return ""
+	}
+	return ts.Format(time.RFC3339)
+}
+
+// UnmarshalText is the implementation of the encoding.TextUnmarshaler
+// interface used when loading the settings from a config file.
+func (ts *Timestamp) UnmarshalText(text []byte) error {
+	return ts.Set(string(text))
+}
+
 var (
 	// SupportedDbTypes represents the supported databases
 	SupportedDbTypes = map[DBType]bool{
 		DBTypePostgresql: true,
 		DBTypeMySQL:      true,
 		DBTypeSQLite:     true,
+		DBTypeCockroach:  true,
+		DBTypeRedshift:   true,
 	}
 
 	// supportedOutputFormats represents the supported output formats
@@ -135,6 +387,8 @@ var (
 		DBTypePostgresql: "5432",
 		DBTypeMySQL:      "3306",
 		DBTypeSQLite:     "",
+		DBTypeCockroach:  "26257",
+		DBTypeRedshift:   "5439",
 	}
 
 	// supportedNullTypes represents the supported types of NULL types
@@ -142,50 +396,205 @@ var (
 		NullTypeSQL:       true,
 		NullTypeNative:    true,
 		NullTypePrimitive: true,
+		NullTypePointers:  true,
+		NullTypePointer:   true,
+		NullTypeZero:      true,
 	}
 
 	// supportedFileNameFormats represents the supported filename formats
 	supportedFileNameFormats = map[FileNameFormat]bool{
 		FileNameFormatCamelCase: true,
 		FileNameFormatSnakeCase: true,
+		FileNameFormatOriginal:  true,
+	}
+
+	// fileNameFormatLongForms maps the long forms of the filename formats to
+	// the supported ones
+	fileNameFormatLongForms = map[string]FileNameFormat{
+		"camel":    FileNameFormatCamelCase,
+		"snake":    FileNameFormatSnakeCase,
+		"original": FileNameFormatOriginal,
+	}
+
+	// supportedTableConsts represents the supported table consts
+	supportedTableConsts = map[TableConst]bool{
+		TableConstNone:   true,
+		TableConstMethod: true,
+		TableConstConst:  true,
+	}
+
+	// supportedFormatters represents the supported formatters
+	supportedFormatters = map[Formatter]bool{
+		FormatterGofmt:     true,
+		FormatterGoimports: true,
+	}
+
+	// supportedSSLModes represents the supported SSL modes
+	supportedSSLModes = map[SSLMode]bool{
+		SSLModeDisable:    true,
+		SSLModeRequire:    true,
+		SSLModeVerifyCA:   true,
+		SSLModeVerifyFull: true,
 	}
 )
 
-// Settings stores the supported settings / command line arguments.
+// TagTemplateData is the data the tag template gets executed with per column.
+type TagTemplateData struct {
+	ColumnName      string
+	DataType        string
+	DefaultValue    string
+	IsNullable      bool
+	IsPrimaryKey    bool
+	IsAutoIncrement bool
+}
+
+// sampleTagTemplateData is the column the tag template gets executed with on
+// verification, to report errors of the execution as well.
+var sampleTagTemplateData = TagTemplateData{
+	ColumnName:      "id",
+	DataType:        "integer",
+	IsPrimaryKey:    true,
+	IsAutoIncrement: true,
+}
+
+// Settings stores the supported settings / command line arguments. The yaml
+// keys are used when loading the settings from a config file.
 type Settings struct {
-	Verbose  bool
-	VVerbose bool
-	Force    bool // continue through errors
+	Verbose  bool `yaml:"verbose"`
+	VVerbose bool `yaml:"vverbose"`
+	Quiet    bool `yaml:"quiet"` // only errors, takes precedence over verbose
+	Force    bool `yaml:"force"` // continue through errors
+
+	FailOnEmpty bool `yaml:"failOnEmpty"` // error instead of warning if no tables are found
+
+	Concurrency int `yaml:"concurrency"`
+
+	DbType DBType `yaml:"dbType"`
+
+	User   string  `yaml:"user"`
+	Pswd   string  `yaml:"pswd"`
+	DbName string  `yaml:"dbName"`
+	Schema string  `yaml:"schema"`
+	Views  bool    `yaml:"views"`
+	Host   string  `yaml:"host"`
+	Port   string  `yaml:"port"`
+	Socket string  `yaml:"socket"`
+	SSL    SSLMode `yaml:"ssl"`
+
+	// DataSourceName replaces the DSN built by the settings above if given.
+	DataSourceName string `yaml:"dsn"`
+
+	// SchemaFile is a JSON file describing the tables, read instead of
+	// connecting to the database.
+	SchemaFile string `yaml:"schemaFile"`
 
-	DbType DBType
+	// Routines adds the results of the functions returning sets of rows,
+	// supported by PostgreSQL only.
+	Routines bool `yaml:"routines"`
 
-	User   string
-	Pswd   string
-	DbName string
-	Schema string
-	Host   string
-	Port   string
-	Socket string
+	Timeout time.Duration `yaml:"timeout"` // zero means no timeout
+	Retries int           `yaml:"retries"`
 
-	OutputFilePath string
-	OutputFormat   OutputFormat
+	// The connection pool of the database, defaults are the ones of
+	// database/sql. Zero max open conns and conn max lifetime mean no limit.
+	MaxOpenConns    int           `yaml:"maxOpenConns"`
+	MaxIdleConns    int           `yaml:"maxIdleConns"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
 
-	FileNameFormat FileNameFormat
-	PackageName    string
-	Prefix         string
-	Suffix         string
-	Null           NullType
+	BatchColumns bool `yaml:"batchColumns"` // one query for the columns of all tables
 
-	NoInitialism bool
+	Include  List `yaml:"include"`
+	Exclude  List `yaml:"exclude"`
+	CITables bool `yaml:"ciTables"` // match include and exclude case-insensitively
 
-	TagsNoDb bool
+	OutputFilePath string       `yaml:"outputFilePath"`
+	OutputFormat   OutputFormat `yaml:"outputFormat"`
+	SingleFile     bool         `yaml:"singleFile"`
+	Append         bool         `yaml:"append"`       // append new structs to the existing single file
+	OutputStdout   bool         `yaml:"outputStdout"` // single file written to stdout
+	SubDirs        bool         `yaml:"subDirs"`
+	NoOverwrite    bool         `yaml:"noOverwrite"`
+	Since          Timestamp    `yaml:"since"` // skip the tables unchanged since then
+	NoFormat       bool         `yaml:"noFormat"`
+	Formatter      Formatter    `yaml:"formatter"`
+	VerifyOutput   bool         `yaml:"verifyOutput"` // type check the generated code
+	Indent         string       `yaml:"indent"`
 
-	TagsMastermindStructable       bool
-	TagsMastermindStructableOnly   bool
-	IsMastermindStructableRecorder bool
+	FileNameFormat FileNameFormat `yaml:"fileNameFormat"`
+	PackageName    string         `yaml:"packageName"`
+	PackageMap     Map            `yaml:"packageMap"` // package names of tables written into sub directories
+	Header         string         `yaml:"header"`
+	HeaderVersion  bool           `yaml:"headerVersion"` // the version of the tool in the header
+	ToolVersion    string         `yaml:"-"`             // set by the tool, not configurable
+	BuildTags      string         `yaml:"buildTags"`
+	Prefix         string         `yaml:"prefix"`
+	Suffix         string         `yaml:"suffix"`
+	TrimPrefix     string         `yaml:"trimPrefix"` // trimmed from the table names
+	Null           NullType       `yaml:"nullType"`
+	TableConst     TableConst     `yaml:"tableConst"`
 
-	// TODO not implemented yet
-	TagsGorm bool
+	NoInitialism     bool `yaml:"noInitialism"`
+	Initialisms      bool `yaml:"initialisms"`      // the common initialisms of golint, eg. APIKey
+	ExtraInitialisms List `yaml:"extraInitialisms"` // upper cased like the common ones, eg. "ssn,ein"
+
+	Unexported   bool   `yaml:"unexported"`
+	Rename       Map    `yaml:"rename"`
+	TypeOverride Map    `yaml:"typeOverride"`
+	DomainMap    Map    `yaml:"domainMap"`
+	IDType       string `yaml:"idType"` // type of integer primary key columns
+	PKFirst      bool   `yaml:"pkFirst"`
+	Embed        List   `yaml:"embed"`
+	EmbedName    string `yaml:"embedName"`
+	ScanHelper   bool   `yaml:"scanHelper"`
+	Getters      bool   `yaml:"getters"`
+	FieldMeta    bool   `yaml:"fieldMeta"`
+	Registry     bool   `yaml:"registry"`
+	ColumnMap    bool   `yaml:"columnMap"`
+	Constructor  bool   `yaml:"constructor"`
+	Fixtures     bool   `yaml:"fixtures"`
+	SQL          bool   `yaml:"sql"`
+	Ent          bool   `yaml:"ent"` // ent schemas instead of structs
+
+	ForeignKeys bool `yaml:"foreignKeys"`
+	Defaults    bool `yaml:"defaults"`
+	Comments    bool `yaml:"comments"`
+	DDLComment  bool `yaml:"ddlComment"`
+
+	UUID    bool `yaml:"uuid"`
+	JSONRaw bool `yaml:"jsonRaw"`
+	Decimal bool `yaml:"decimal"`
+
+	BlobBytes bool `yaml:"blobBytes"`
+
+	TinyIntBool bool `yaml:"tinyintBool"`
+
+	NetStrings bool `yaml:"netStrings"`
+
+	Hstore bool `yaml:"hstore"`
+
+	GeomType string `yaml:"geomType"`
+
+	IntervalType string `yaml:"intervalType"`
+
+	EnumConsts bool `yaml:"enumConsts"`
+
+	TagsNoDb bool `yaml:"tagsNoDb"`
+
+	TagsMastermindStructable       bool `yaml:"tagsStructable"`
+	TagsMastermindStructableOnly   bool `yaml:"tagsStructableOnly"`
+	IsMastermindStructableRecorder bool `yaml:"structableRecorder"`
+
+	TagsGorm bool `yaml:"tagsGorm"`
+	TagsBun  bool `yaml:"tagsBun"`
+
+	TagsJSON  bool `yaml:"tagsJSON"`
+	OmitEmpty bool `yaml:"omitEmpty"`
+
+	TagsMapstructure bool `yaml:"tagsMapstructure"`
+
+	TagsValidate bool `yaml:"tagsValidate"`
+
+	TagTemplate string `yaml:"tagTemplate"`
 }
 
 // New constructs Settings with default values.
@@ -199,25 +608,107 @@ func New() *Settings {
 	return &Settings{
 		Verbose:  false,
 		VVerbose: false,
+		Quiet:    false,
 		Force:    false,
 
+		FailOnEmpty: false,
+
+		Concurrency: runtime.NumCPU(),
+
 		DbType:         DBTypePostgresql,
 		User:           "",
 		Pswd:           "",
 		DbName:         "postgres",
 		Schema:         "public",
+		Views:          false,
 		Host:           "127.0.0.1",
 		Port:           "", // left blank, automatically determined if not set
 		Socket:         "",
+		SSL:            SSLModeDisable,
+		DataSourceName: "",
+		SchemaFile:     "",
+		Routines:       false,
+		Timeout:        0,
+		Retries:        0,
+
+		MaxOpenConns:    0,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 0,
+
+		BatchColumns: false,
+
+		Include:        nil,
+		Exclude:        nil,
+		CITables:       false,
 		OutputFilePath: dir,
 		OutputFormat:   OutputFormatCamelCase,
+		SingleFile:     false,
+		Append:         false,
+		OutputStdout:   false,
+		SubDirs:        false,
+		NoOverwrite:    false,
+		Since:          Timestamp{},
+		NoFormat:       false,
+		Formatter:      FormatterGofmt,
+		VerifyOutput:   false,
+		Indent:         `\t`,
 		FileNameFormat: FileNameFormatCamelCase,
 		PackageName:    "dto",
+		PackageMap:     nil,
+		Header:         "Code generated by tables-to-go; DO NOT EDIT.",
+		HeaderVersion:  false,
+		ToolVersion:    "",
+		BuildTags:      "",
 		Prefix:         "",
 		Suffix:         "",
+		TrimPrefix:     "",
 		Null:           NullTypeSQL,
+		TableConst:     TableConstNone,
+
+		NoInitialism:     false,
+		Initialisms:      false,
+		ExtraInitialisms: nil,
+
+		Unexported:   false,
+		Rename:       nil,
+		TypeOverride: nil,
+		DomainMap:    nil,
+		IDType:       "",
+		PKFirst:      false,
+		Embed:        nil,
+		EmbedName:    "AuditFields",
+		ScanHelper:   false,
+		Getters:      false,
+		FieldMeta:    false,
+		Registry:     false,
+		ColumnMap:    false,
+		Constructor:  false,
+		Fixtures:     false,
+		SQL:          false,
+		Ent:          false,
+
+		ForeignKeys: false,
+		Defaults:    false,
+		Comments:    false,
+		DDLComment:  false,
+
+		UUID:    false,
+		JSONRaw: false,
+		Decimal: false,
+
+		BlobBytes: false,
+
+		TinyIntBool: false,
 
-		NoInitialism: false,
+		NetStrings: false,
+
+		Hstore: false,
+
+		GeomType: "[]byte",
+
+		IntervalType: "string",
+
+		EnumConsts: false,
 
 		TagsNoDb: false,
 
@@ -226,18 +717,52 @@ func New() *Settings {
 		IsMastermindStructableRecorder: false,
 
 		TagsGorm: false,
+		TagsBun:  false,
+
+		TagsJSON:  false,
+		OmitEmpty: false,
+
+		TagsMapstructure: false,
+
+		TagsValidate: false,
+
+		TagTemplate: "",
+	}
+}
+
+// LoadConfigFile loads the settings from the given YAML file. The keys of the
+// file correspond to the yaml keys of the Settings. Settings not specified in
+// the file keep their current values.
+func (settings *Settings) LoadConfigFile(path string) error {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open config file %q: %w", path, err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+
+	if err = decoder.Decode(settings); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("could not parse config file %q: %w", path, err)
 	}
+
+	return nil
 }
 
 // Verify verifies the Settings and checks the given output paths.
 func (settings *Settings) Verify() (err error) {
 
-	if err = settings.verifyOutputPath(); err != nil {
-		return err
-	}
+	// nothing gets written into the output file path when writing to stdout
+	if !settings.OutputStdout {
+		if err = settings.verifyOutputPath(); err != nil {
+			return err
+		}
 
-	if settings.OutputFilePath, err = settings.prepareOutputPath(); err != nil {
-		return err
+		if settings.OutputFilePath, err = settings.prepareOutputPath(); err != nil {
+			return err
+		}
 	}
 
 	if settings.Port == "" {
@@ -248,6 +773,150 @@ func (settings *Settings) Verify() (err error) {
 		return fmt.Errorf("name of package can not be empty")
 	}
 
+	if token.IsKeyword(settings.PackageName) {
+		return fmt.Errorf("name of package %q is a reserved keyword", settings.PackageName)
+	}
+
+	if !token.IsIdentifier(settings.PackageName) {
+		return fmt.Errorf("name of package %q is not a valid identifier", settings.PackageName)
+	}
+
+	for table, pkg := range settings.PackageMap {
+		if token.IsKeyword(pkg) || !token.IsIdentifier(pkg) {
+			return fmt.Errorf("name of package %q of table %q is not a valid identifier", pkg, table)
+		}
+	}
+
+	if settings.BuildTags != "" {
+		if _, err = settings.BuildConstraint(); err != nil {
+			return fmt.Errorf("invalid build tags %q: %w", settings.BuildTags, err)
+		}
+	}
+
+	if settings.GeomType == "" {
+		return errors.New("type of geometry columns must not be empty")
+	}
+
+	if settings.IntervalType == "" {
+		return errors.New("type of interval columns must not be empty")
+	}
+
+	for _, patterns := range []List{settings.Include, settings.Exclude} {
+		for _, pattern := range patterns {
+			if _, err = path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid table pattern %q: %w", pattern, err)
+			}
+		}
+	}
+
+	for column, name := range settings.Rename {
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("name %q of column %q is not a valid identifier", name, column)
+		}
+	}
+
+	for column, typ := range settings.TypeOverride {
+		if !isValidType(typ) {
+			return fmt.Errorf("type %q of column %q is not a valid type", typ, column)
+		}
+	}
+
+	for domain, typ := range settings.DomainMap {
+		if !isValidType(typ) {
+			return fmt.Errorf("type %q of domain %q is not a valid type", typ, domain)
+		}
+	}
+
+	if settings.IDType != "" && !isValidType(settings.IDType) {
+		return fmt.Errorf("type %q of primary key columns is not a valid type", settings.IDType)
+	}
+
+	if len(settings.Embed) > 0 && !(token.IsIdentifier(settings.EmbedName) && token.IsExported(settings.EmbedName)) {
+		return fmt.Errorf("name %q of the embedded struct is not a valid exported identifier", settings.EmbedName)
+	}
+
+	tagTemplate, err := template.New("tag").Parse(settings.TagTemplate)
+	if err != nil {
+		return fmt.Errorf("could not parse tag template: %w", err)
+	}
+	if err = tagTemplate.Execute(io.Discard, sampleTagTemplateData); err != nil {
+		return fmt.Errorf("could not execute tag template: %w", err)
+	}
+
+	if settings.Initialisms && settings.NoInitialism {
+		return errors.New("initialisms and no initialism cannot be combined")
+	}
+
+	if len(settings.ExtraInitialisms) > 0 && !settings.Initialisms {
+		return errors.New("extra initialisms require initialisms")
+	}
+
+	if settings.SingleFile && settings.SubDirs {
+		return errors.New("single file and sub directories per schema cannot be combined")
+	}
+
+	if settings.OutputStdout && settings.SubDirs {
+		return errors.New("output to stdout and sub directories per schema cannot be combined")
+	}
+
+	if settings.Append && !settings.SingleFile {
+		return errors.New("append requires the structs to be written into a single file")
+	}
+
+	if settings.Append && settings.NoOverwrite {
+		return errors.New("append and no overwrite cannot be combined")
+	}
+
+	if len(settings.PackageMap) > 0 && (settings.SingleFile || settings.OutputStdout) {
+		return errors.New("package mapping cannot be combined with single file or output to stdout")
+	}
+
+	if !settings.Since.IsZero() && (settings.SingleFile || settings.OutputStdout) {
+		return errors.New("since, which tracks the file of each table, cannot be combined with single file or output to stdout")
+	}
+
+	if settings.Ent && (len(settings.Embed) > 0 || settings.FieldMeta || settings.Routines || settings.Unexported) {
+		return errors.New("ent schemas cannot be combined with embedded structs, field descriptors, routines or unexported names")
+	}
+
+	if settings.SchemaFile != "" && settings.Routines {
+		return errors.New("routines cannot be read from a schema file")
+	}
+
+	// the query of the routines relies on pg_proc along with lateral joins,
+	// neither Redshift nor the other databases support them
+	if settings.Routines && settings.DbType != DBTypePostgresql {
+		return fmt.Errorf("routines are only supported for %s, not for %s", DBTypePostgresql, settings.DbType)
+	}
+
+	if settings.OutputStdout && settings.VerifyOutput {
+		return errors.New("output to stdout cannot be type checked, it is not written to the output file path")
+	}
+
+	if settings.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, got %v", settings.Timeout)
+	}
+
+	if settings.Retries < 0 {
+		return fmt.Errorf("retries must not be negative, got %d", settings.Retries)
+	}
+
+	if settings.MaxOpenConns < 0 {
+		return fmt.Errorf("max open conns must not be negative, got %d", settings.MaxOpenConns)
+	}
+
+	if settings.MaxIdleConns < 0 {
+		return fmt.Errorf("max idle conns must not be negative, got %d", settings.MaxIdleConns)
+	}
+
+	if settings.ConnMaxLifetime < 0 {
+		return fmt.Errorf("conn max lifetime must not be negative, got %v", settings.ConnMaxLifetime)
+	}
+
+	if settings.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", settings.Concurrency)
+	}
+
 	if settings.VVerbose {
 		settings.Verbose = true
 	}
@@ -255,6 +924,21 @@ func (settings *Settings) Verify() (err error) {
 	return err
 }
 
+// isValidType checks if the type, optionally qualified by the path of its
+// package, is a valid go type.
+func isValidType(typ string) bool {
+	// the path of the package is no part of go code, its name is
+	src := "package p\nvar _ " + typ[strings.LastIndex(typ, "/")+1:]
+	_, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	return err == nil
+}
+
+// BuildConstraint parses the build tags into the expression of a build
+// constraint.
+func (settings *Settings) BuildConstraint() (constraint.Expr, error) {
+	return constraint.Parse("//go:build " + settings.BuildTags)
+}
+
 func (settings *Settings) verifyOutputPath() (err error) {
 
 	info, err := os.Stat(settings.OutputFilePath)
@@ -263,17 +947,74 @@ func (settings *Settings) verifyOutputPath() (err error) {
 		return fmt.Errorf("output file path %q does not exists", settings.OutputFilePath)
 	}
 
+	if err != nil {
+		return fmt.Errorf("could not access output file path %q: %w", settings.OutputFilePath, err)
+	}
+
 	if !info.Mode().IsDir() {
 		return fmt.Errorf("output file path %q is not a directory", settings.OutputFilePath)
 	}
 
-	return err
+	return settings.verifyOutputPathWritable()
+}
+
+// verifyOutputPathWritable checks if files can be created in the output file
+// path by creating and removing a temporary file, so that the missing
+// permissions are reported before connecting to the database.
+func (settings *Settings) verifyOutputPathWritable() error {
+
+	file, err := os.CreateTemp(settings.OutputFilePath, ".tables-to-go-*")
+	if err != nil {
+		return fmt.Errorf("output file path %q is not writable: %w", settings.OutputFilePath, err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("output file path %q is not writable: %w", settings.OutputFilePath, err)
+	}
+
+	return os.Remove(file.Name())
+}
+
+// DerivePackageName sets the package name to the base name of the output file
+// path, if no package name is set. Like the go convention, the derived name is
+// lower case without any characters invalid in identifiers, eg. `my-models`
+// becomes `mymodels`.
+func (settings *Settings) DerivePackageName() error {
+
+	if settings.PackageName != "" {
+		return nil
+	}
+
+	outputFilePath, err := settings.prepareOutputPath()
+	if err != nil {
+		return fmt.Errorf("could not derive name of package from output file path %q: %w", settings.OutputFilePath, err)
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, filepath.Base(outputFilePath))
+	name = strings.TrimLeftFunc(name, unicode.IsDigit)
+
+	if name == "" {
+		return fmt.Errorf("could not derive name of package from output file path %q, it must be given", outputFilePath)
+	}
+
+	if token.IsKeyword(name) {
+		return fmt.Errorf("name of package %q derived from output file path %q is a reserved keyword, it must be given", name, outputFilePath)
+	}
+
+	settings.PackageName = name
+
+	return nil
 }
 
+// prepareOutputPath makes the output file path absolute. The files get joined
+// to it, it does not end with a separator.
 func (settings *Settings) prepareOutputPath() (outputFilePath string, err error) {
-	outputFilePath, err = filepath.Abs(settings.OutputFilePath)
-	outputFilePath += string(filepath.Separator)
-	return outputFilePath, err
+	return filepath.Abs(settings.OutputFilePath)
 }
 
 // SprintfSupportedDbTypes returns a slice of strings as names of the supported
@@ -296,26 +1037,176 @@ func SprintfSupportedNullTypes() string {
 	return fmt.Sprintf("%v", names)
 }
 
+// SprintfSupportedSSLModes returns a slice of strings as names of the
+// supported SSL modes
+func SprintfSupportedSSLModes() string {
+	names := []string{
+		string(SSLModeDisable),
+		string(SSLModeRequire),
+		string(SSLModeVerifyCA),
+		string(SSLModeVerifyFull),
+	}
+	return fmt.Sprintf("%v", names)
+}
+
+// SprintfSupportedFormatters returns a slice of strings as names of the
+// supported formatters
+func SprintfSupportedFormatters() string {
+	names := []string{
+		string(FormatterGofmt),
+		string(FormatterGoimports),
+	}
+	return fmt.Sprintf("%v", names)
+}
+
 // IsNullTypeSQL returns true if the type given by the command line args is of
 // null type SQL
 func (settings *Settings) IsNullTypeSQL() bool {
 	return settings.Null == NullTypeSQL
 }
 
+// IsNullTypeZero returns true if the type given by the command line args is of
+// null type zero
+func (settings *Settings) IsNullTypeZero() bool {
+	return settings.Null == NullTypeZero
+}
+
 // ShouldInitialism returns whether column names should be converted
 // to initialisms or not.
 func (settings *Settings) ShouldInitialism() bool {
 	return !settings.NoInitialism
 }
 
+// ShouldCommonInitialisms returns whether the whole words of column names
+// which are common initialisms, or extra ones, should be upper cased beyond
+// the default initialisms.
+func (settings *Settings) ShouldCommonInitialisms() bool {
+	return settings.Initialisms && settings.ShouldInitialism()
+}
+
 // IsOutputFormatCamelCase returns if the type given by command line args is of
 // camel-case format.
 func (settings *Settings) IsOutputFormatCamelCase() bool {
 	return settings.OutputFormat == OutputFormatCamelCase
 }
 
+// HeaderString returns the header of the generated files. The line naming the
+// version of the tool gets appended if enabled, it is the header alone if no
+// header is given.
+func (settings *Settings) HeaderString() string {
+	if !settings.HeaderVersion {
+		return settings.Header
+	}
+
+	line := "Generated by tables-to-go."
+	if settings.ToolVersion != "" {
+		line = "Generated by tables-to-go " + settings.ToolVersion + "."
+	}
+	if settings.Header == "" {
+		return line
+	}
+	return settings.Header + "\n" + line
+}
+
+// IndentString returns the indentation of the unformatted output with the
+// escaped tabs replaced by real ones.
+func (settings *Settings) IndentString() string {
+	return strings.ReplaceAll(settings.Indent, `\t`, "\t")
+}
+
+// Schemas returns the schemas to generate the structs for, given as comma
+// separated list. The schemas of MySQL are its databases.
+func (settings *Settings) Schemas() []string {
+	schemas := settings.Schema
+	if settings.DbType == DBTypeMySQL {
+		schemas = settings.DbName
+	}
+	var list List
+	_ = list.Set(schemas)
+	return list
+}
+
+// RenamedColumn returns the name given by the rename mapping for the column of
+// the table, either by the qualified name table.column or by the column name.
+func (settings *Settings) RenamedColumn(table, column string) (string, bool) {
+	if name, ok := settings.Rename[table+"."+column]; ok {
+		return name, true
+	}
+	name, ok := settings.Rename[column]
+	return name, ok
+}
+
+// MappedPackage returns the name of the package given by the package mapping
+// for the table of the schema, either by the qualified name schema.table or by
+// the table name.
+func (settings *Settings) MappedPackage(schema, table string) (string, bool) {
+	if pkg, ok := settings.PackageMap[schema+"."+table]; ok && schema != "" {
+		return pkg, true
+	}
+	pkg, ok := settings.PackageMap[table]
+	return pkg, ok
+}
+
+// OverriddenType returns the go type given by the type override mapping for
+// the column of the table, either by the qualified name table.column or by the
+// column name.
+func (settings *Settings) OverriddenType(table, column string) (string, bool) {
+	if typ, ok := settings.TypeOverride[table+"."+column]; ok {
+		return typ, true
+	}
+	typ, ok := settings.TypeOverride[column]
+	return typ, ok
+}
+
+// IsTableIncluded returns if the table with the given name matches the include
+// patterns, if any, and none of the exclude patterns. The name is the one as
+// stored by the database, it is matched case-insensitively if enabled.
+func (settings *Settings) IsTableIncluded(name string) bool {
+	for _, pattern := range settings.Exclude {
+		if settings.matchTable(pattern, name) {
+			return false
+		}
+	}
+	if len(settings.Include) == 0 {
+		return true
+	}
+	for _, pattern := range settings.Include {
+		if settings.matchTable(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTable reports whether the name of the table matches the pattern.
+func (settings *Settings) matchTable(pattern, name string) bool {
+	if settings.CITables {
+		pattern, name = strings.ToLower(pattern), strings.ToLower(name)
+	}
+	matched, _ := path.Match(pattern, name)
+	return matched
+}
+
+// IsTableConstMethod returns if the name of the table should be generated as
+// TableName method of the struct.
+func (settings *Settings) IsTableConstMethod() bool {
+	return settings.TableConst == TableConstMethod
+}
+
+// IsTableConstConst returns if the name of the table should be generated as
+// constant along with the struct.
+func (settings *Settings) IsTableConstConst() bool {
+	return settings.TableConst == TableConstConst
+}
+
 // IsFileNameFormatSnakeCase returns if the type given by the command line args
 // is snake-case format.
 func (settings *Settings) IsFileNameFormatSnakeCase() bool {
 	return settings.FileNameFormat == FileNameFormatSnakeCase
 }
+
+// IsFileNameFormatOriginal returns if the type given by the command line args
+// is the original name of the table.
+func (settings *Settings) IsFileNameFormatOriginal() bool {
+	return settings.FileNameFormat == FileNameFormatOriginal
+}
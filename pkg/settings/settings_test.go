@@ -1,9 +1,14 @@
 package settings
 
 import (
+	"errors"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -48,6 +53,412 @@ func TestSettings_Verify(t *testing.T) {
 			},
 			isError: assert.Error,
 		},
+		{
+			desc: "valid build tags produce no error",
+			settings: func() *Settings {
+				s := New()
+				s.BuildTags = "integration && !windows"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "invalid build tags produce error",
+			settings: func() *Settings {
+				s := New()
+				s.BuildTags = "integration &&"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "empty geometry type produces error",
+			settings: func() *Settings {
+				s := New()
+				s.GeomType = ""
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "empty interval type produces error",
+			settings: func() *Settings {
+				s := New()
+				s.IntervalType = ""
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "unexported name of embedded struct produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Embed = List{"created_at"}
+				s.EmbedName = "auditFields"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "concurrency less than 1 produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Concurrency = 0
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "invalid table pattern produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Include = List{"user_[*"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "invalid tag template produces error",
+			settings: func() *Settings {
+				s := New()
+				s.TagTemplate = `pg:"{{.ColumnName"`
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "tag template of an unknown field produces error",
+			settings: func() *Settings {
+				s := New()
+				s.TagTemplate = `pg:"{{.Unknown}}"`
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "tag template of the column properties produces no error",
+			settings: func() *Settings {
+				s := New()
+				s.TagTemplate = `pg:"{{.ColumnName}}{{if .IsPrimaryKey}},pk{{end}}" type:"{{.DataType}}"`
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "single file with sub directories produces error",
+			settings: func() *Settings {
+				s := New()
+				s.SingleFile = true
+				s.SubDirs = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "wrong output file path is ignored for output to stdout",
+			settings: func() *Settings {
+				s := New()
+				s.OutputFilePath = ""
+				s.OutputStdout = true
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "output to stdout combined with sub directories produces error",
+			settings: func() *Settings {
+				s := New()
+				s.OutputStdout = true
+				s.SubDirs = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "append without single file produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Append = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "append combined with no overwrite produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Append = true
+				s.SingleFile = true
+				s.NoOverwrite = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "append combined with single file is valid",
+			settings: func() *Settings {
+				s := New()
+				s.Append = true
+				s.SingleFile = true
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "since combined with single file produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Since = Timestamp{Time: time.Now()}
+				s.SingleFile = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "output to stdout combined with verify produces error",
+			settings: func() *Settings {
+				s := New()
+				s.OutputStdout = true
+				s.VerifyOutput = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative timeout produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Timeout = -time.Second
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative retries produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Retries = -1
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "ent schemas with embedded structs produce error",
+			settings: func() *Settings {
+				s := New()
+				s.Ent = true
+				s.Embed = List{"created_at", "updated_at"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "ent schemas with field descriptors produce error",
+			settings: func() *Settings {
+				s := New()
+				s.Ent = true
+				s.FieldMeta = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "initialisms along with no initialism produce error",
+			settings: func() *Settings {
+				s := New()
+				s.Initialisms = true
+				s.NoInitialism = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "extra initialisms without initialisms produce error",
+			settings: func() *Settings {
+				s := New()
+				s.ExtraInitialisms = List{"ssn"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "extra initialisms along with initialisms produce no error",
+			settings: func() *Settings {
+				s := New()
+				s.Initialisms = true
+				s.ExtraInitialisms = List{"ssn"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "routines from a schema file produce error",
+			settings: func() *Settings {
+				s := New()
+				s.SchemaFile = "schema.json"
+				s.Routines = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "routines of mysql produce error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.Routines = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "routines of sqlite produce error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeSQLite
+				s.Routines = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "routines of redshift produce error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeRedshift
+				s.Routines = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "routines of cockroach produce error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeCockroach
+				s.Routines = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "routines of postgresql produce no error",
+			settings: func() *Settings {
+				s := New()
+				s.Routines = true
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "negative max open conns produces error",
+			settings: func() *Settings {
+				s := New()
+				s.MaxOpenConns = -1
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative max idle conns produces error",
+			settings: func() *Settings {
+				s := New()
+				s.MaxIdleConns = -1
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative conn max lifetime produces error",
+			settings: func() *Settings {
+				s := New()
+				s.ConnMaxLifetime = -time.Minute
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "rename to invalid identifier produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Rename = Map{"old_col": "New-Name"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "type override with qualified types produces no error",
+			settings: func() *Settings {
+				s := New()
+				s.TypeOverride = Map{"status": "Status", "users.ip": "*github.com/example/types.IP", "tags": "[]string"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "package map with valid package names produces no error",
+			settings: func() *Settings {
+				s := New()
+				s.PackageMap = Map{"users": "auth", "public.orders": "commerce"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "package map with keyword produces error",
+			settings: func() *Settings {
+				s := New()
+				s.PackageMap = Map{"users": "type"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "package map with invalid identifier produces error",
+			settings: func() *Settings {
+				s := New()
+				s.PackageMap = Map{"users": "my-auth"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "package map combined with single file produces error",
+			settings: func() *Settings {
+				s := New()
+				s.PackageMap = Map{"users": "auth"}
+				s.SingleFile = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "type override to invalid type produces error",
+			settings: func() *Settings {
+				s := New()
+				s.TypeOverride = Map{"status": "Status-Type"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "invalid id type produces error",
+			settings: func() *Settings {
+				s := New()
+				s.IDType = "My ID"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "domain map to invalid type produces error",
+			settings: func() *Settings {
+				s := New()
+				s.DomainMap = Map{"email": "mail address"}
+				return s
+			},
+			isError: assert.Error,
+		},
 		{
 			desc: "set v-verbose mode activates verbose mode without error",
 			settings: func() *Settings {
@@ -60,9 +471,392 @@ func TestSettings_Verify(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			settings := test.settings()
-			err := settings.Verify()
-			test.isError(t, err)
+			settings := test.settings()
+			err := settings.Verify()
+			test.isError(t, err)
+		})
+	}
+}
+
+func TestSettings_LoadConfigFile(t *testing.T) {
+	tests := []struct {
+		desc     string
+		content  string
+		expected func() *Settings
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "empty config file keeps default settings",
+			content:  "",
+			expected: New,
+			isError:  assert.NoError,
+		},
+		{
+			desc: "given keys overwrite default settings",
+			content: `
+dbType: mysql
+user: root
+dbName: testdb
+packageName: models
+nullType: native
+tagsGorm: true
+`,
+			expected: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.User = "root"
+				s.DbName = "testdb"
+				s.PackageName = "models"
+				s.Null = NullTypeNative
+				s.TagsGorm = true
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "table patterns as comma separated list and as sequence",
+			content: `
+include: user_*,order_*
+exclude:
+  - user_tmp
+  - "*_old"
+`,
+			expected: func() *Settings {
+				s := New()
+				s.Include = List{"user_*", "order_*"}
+				s.Exclude = List{"user_tmp", "*_old"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:    "timestamp in the format of RFC 3339",
+			content: "since: 2024-01-02T15:04:05Z",
+			expected: func() *Settings {
+				s := New()
+				s.Since = Timestamp{Time: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:    "version in the header",
+			content: "headerVersion: true",
+			expected: func() *Settings {
+				s := New()
+				s.HeaderVersion = true
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:    "unsupported database type produces error",
+			content: "dbType: oracle",
+			isError: assert.Error,
+		},
+		{
+			desc:    "unknown key produces error",
+			content: "database: testdb",
+			isError: assert.Error,
+		},
+		{
+			desc:    "invalid YAML produces error",
+			content: "dbType: [mysql",
+			isError: assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "config.yml")
+			if err := os.WriteFile(file, []byte(test.content), 0666); err != nil {
+				t.Fatalf("expected non error, got: %s", err)
+			}
+
+			actual := New()
+			err := actual.LoadConfigFile(file)
+			test.isError(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.expected(), actual)
+		})
+	}
+
+	t.Run("not existing config file produces error", func(t *testing.T) {
+		err := New().LoadConfigFile(filepath.Join(t.TempDir(), "missing.yml"))
+		assert.Error(t, err)
+	})
+}
+
+func TestSettings_VerifyPackageName(t *testing.T) {
+	tests := []struct {
+		desc        string
+		packageName string
+		isError     assert.ErrorAssertionFunc
+	}{
+		{
+			desc:        "valid package name produces no error",
+			packageName: "models",
+			isError:     assert.NoError,
+		},
+		{
+			desc:        "keyword func produces error",
+			packageName: "func",
+			isError:     assert.Error,
+		},
+		{
+			desc:        "keyword type produces error",
+			packageName: "type",
+			isError:     assert.Error,
+		},
+		{
+			desc:        "keyword interface produces error",
+			packageName: "interface",
+			isError:     assert.Error,
+		},
+		{
+			desc:        "keyword select produces error",
+			packageName: "select",
+			isError:     assert.Error,
+		},
+		{
+			desc:        "invalid identifier produces error",
+			packageName: "my-models",
+			isError:     assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			s.PackageName = test.packageName
+			test.isError(t, s.Verify())
+		})
+	}
+}
+
+func TestSettings_VerifyOutputPathStatError(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stat of a path below a regular file fails as not existing on windows")
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0666); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	s := New()
+	// stat of a path below a regular file fails with ENOTDIR, not ENOENT
+	s.OutputFilePath = filepath.Join(file, "sub")
+
+	var err error
+	assert.NotPanics(t, func() {
+		err = s.Verify()
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not access output file path")
+	var pathErr *fs.PathError
+	assert.True(t, errors.As(err, &pathErr))
+}
+
+func TestSettings_VerifyOutputPathAbsolute(t *testing.T) {
+	dir := t.TempDir()
+
+	s := New()
+	s.OutputFilePath = dir + string(filepath.Separator) + "."
+
+	assert.NoError(t, s.Verify())
+	assert.Equal(t, dir, s.OutputFilePath)
+}
+
+func TestSettings_DerivePackageName(t *testing.T) {
+	tests := []struct {
+		desc        string
+		packageName string
+		dir         string
+		expected    string
+		isError     assert.ErrorAssertionFunc
+	}{
+		{
+			desc:        "given package name is kept",
+			packageName: "dto",
+			dir:         "models",
+			expected:    "dto",
+			isError:     assert.NoError,
+		},
+		{
+			desc:     "package name gets derived from the output file path",
+			dir:      "models",
+			expected: "models",
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "derived package name is lower case without invalid characters",
+			dir:      "2nd-Data_Models.v2",
+			expected: "nddatamodelsv2",
+			isError:  assert.NoError,
+		},
+		{
+			desc:    "derived package name without valid characters produces error",
+			dir:     "123",
+			isError: assert.Error,
+		},
+		{
+			desc:    "derived package name being a keyword produces error",
+			dir:     "Type",
+			isError: assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			s.PackageName = test.packageName
+			s.OutputFilePath = filepath.Join(t.TempDir(), test.dir)
+
+			err := s.DerivePackageName()
+			test.isError(t, err)
+			assert.Equal(t, test.expected, s.PackageName)
+		})
+	}
+}
+
+func TestSettings_VerifyOutputPathWritable(t *testing.T) {
+	t.Run("writable output file path leaves no files behind", func(t *testing.T) {
+		s := New()
+		s.OutputFilePath = t.TempDir()
+
+		assert.NoError(t, s.Verify())
+
+		entries, err := os.ReadDir(s.OutputFilePath)
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("not writable output file path produces error", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("read-only directories are writable on windows")
+		}
+		if os.Geteuid() == 0 {
+			t.Skip("root can write into read-only directories")
+		}
+
+		s := New()
+		s.OutputFilePath = t.TempDir()
+		if err := os.Chmod(s.OutputFilePath, 0555); err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+		defer os.Chmod(s.OutputFilePath, 0755)
+
+		err := s.Verify()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is not writable")
+	})
+}
+
+func TestSettings_Schemas(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		expected []string
+	}{
+		{
+			desc:     "default settings return the default schema",
+			settings: New,
+			expected: []string{"public"},
+		},
+		{
+			desc: "comma separated schemas get split",
+			settings: func() *Settings {
+				s := New()
+				s.Schema = "public, audit"
+				return s
+			},
+			expected: []string{"public", "audit"},
+		},
+		{
+			desc: "schemas of mysql are the databases",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.DbName = "app,audit"
+				return s
+			},
+			expected: []string{"app", "audit"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.settings().Schemas())
+		})
+	}
+}
+
+func TestSettings_IsTableIncluded(t *testing.T) {
+	tests := []struct {
+		desc     string
+		include  List
+		exclude  List
+		ci       bool
+		name     string
+		expected bool
+	}{
+		{
+			desc:     "without patterns all tables are included",
+			name:     "user_account",
+			expected: true,
+		},
+		{
+			desc:     "table matching include pattern is included",
+			include:  List{"order_*", "user_*"},
+			name:     "user_account",
+			expected: true,
+		},
+		{
+			desc:     "table not matching include pattern is not included",
+			include:  List{"order_*"},
+			name:     "user_account",
+			expected: false,
+		},
+		{
+			desc:     "table matching exclude pattern is not included",
+			exclude:  List{"*_account"},
+			name:     "user_account",
+			expected: false,
+		},
+		{
+			desc:     "exclude pattern takes precedence over include pattern",
+			include:  List{"user_*"},
+			exclude:  List{"user_account"},
+			name:     "user_account",
+			expected: false,
+		},
+		{
+			desc:     "mixed-case table not matching lower-case include pattern is not included",
+			include:  List{"useraccounts"},
+			name:     "UserAccounts",
+			expected: false,
+		},
+		{
+			desc:     "mixed-case table matching include pattern case-insensitively is included",
+			include:  List{"user*"},
+			ci:       true,
+			name:     "UserAccounts",
+			expected: true,
+		},
+		{
+			desc:     "mixed-case table matching exclude pattern case-insensitively is not included",
+			exclude:  List{"USERACCOUNTS"},
+			ci:       true,
+			name:     "UserAccounts",
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			s.Include = test.include
+			s.Exclude = test.exclude
+			s.CITables = test.ci
+			assert.Equal(t, test.expected, s.IsTableIncluded(test.name))
 		})
 	}
 }
@@ -105,6 +899,15 @@ func TestSettings_IsNullTypeSQL(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			desc: "pointers NULL type deativates sql NULL type",
+			settings: func() *Settings {
+				s := New()
+				s.Null = NullTypePointers
+				return s
+			},
+			expected: false,
+		},
 		{
 			desc: "any other NULL type deativates sql NULL type",
 			settings: func() *Settings {
@@ -163,6 +966,46 @@ func TestSettings_ShouldInitialism(t *testing.T) {
 	}
 }
 
+func TestSettings_ShouldCommonInitialisms(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		expected bool
+	}{
+		{
+			desc:     "in default settings common initialisms are deactivated",
+			settings: New,
+			expected: false,
+		},
+		{
+			desc: "enabled initialisms activate common initialisms",
+			settings: func() *Settings {
+				s := New()
+				s.Initialisms = true
+				return s
+			},
+			expected: true,
+		},
+		{
+			desc: "disabled initialism deactivates common initialisms",
+			settings: func() *Settings {
+				s := New()
+				s.Initialisms = true
+				s.NoInitialism = true
+				return s
+			},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			settings := test.settings()
+			actual := settings.ShouldCommonInitialisms()
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestSettings_IsOutputFormatCamelCase(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -311,6 +1154,12 @@ func TestNullType_Set(t *testing.T) {
 			expected: NullTypeNative,
 			isError:  assert.NoError,
 		},
+		{
+			desc:     "string typed pointers NULL type produces no error and gets set",
+			input:    string("pointers"),
+			expected: NullTypePointers,
+			isError:  assert.NoError,
+		},
 		{
 			desc:     "empty NULL type produces no error and gets default",
 			input:    "",
@@ -395,6 +1244,18 @@ func TestFileNameFormat_Set(t *testing.T) {
 			expected: FileNameFormatCamelCase,
 			isError:  assert.NoError,
 		},
+		{
+			desc:     "original filename type produces no error and gets set",
+			input:    "o",
+			expected: FileNameFormatOriginal,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "long form of filename type produces no error and gets set",
+			input:    "snake",
+			expected: FileNameFormatSnakeCase,
+			isError:  assert.NoError,
+		},
 		{
 			desc:     "empty output type produces no error and gets default",
 			input:    "",
@@ -418,6 +1279,230 @@ func TestFileNameFormat_Set(t *testing.T) {
 	}
 }
 
+func TestTableConst_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected TableConst
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "method produces no error and gets set",
+			input:    "method",
+			expected: TableConstMethod,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "const produces no error and gets set",
+			input:    "const",
+			expected: TableConstConst,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty table const produces no error and gets disabled",
+			input:    "",
+			expected: TableConstNone,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "unsupported table const produces error",
+			input:    "var",
+			expected: TableConst("var"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := TableConstNone
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestList_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected List
+	}{
+		{
+			desc:     "comma separated values get split",
+			input:    "user_*,order_*",
+			expected: List{"user_*", "order_*"},
+		},
+		{
+			desc:     "spaces around values and empty values get removed",
+			input:    " user_* , ,order_*,",
+			expected: List{"user_*", "order_*"},
+		},
+		{
+			desc:     "empty string produces empty list",
+			input:    "",
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := List{"previous"}
+			err := actual.Set(test.input)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestMap_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected Map
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "comma separated pairs get split",
+			input:    "old_col:NewName, users.col : Other",
+			expected: Map{"old_col": "NewName", "users.col": "Other"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty string produces empty map",
+			input:    "",
+			expected: Map{},
+			isError:  assert.NoError,
+		},
+		{
+			desc:    "pair without value produces error",
+			input:   "old_col:,other:Name",
+			isError: assert.Error,
+		},
+		{
+			desc:    "value without key produces error",
+			input:   "NewName",
+			isError: assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := Map{"previous": "Previous"}
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			if err == nil {
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSSLMode_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected SSLMode
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "typed supported ssl mode produces no error and gets set",
+			input:    string(SSLModeVerifyCA),
+			expected: SSLModeVerifyCA,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed supported ssl mode produces no error and gets set",
+			input:    "require",
+			expected: SSLModeRequire,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty ssl mode produces no error and gets default",
+			input:    "",
+			expected: SSLModeDisable,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed unsupported ssl mode produces error and invalid ssl mode",
+			input:    "prefer",
+			expected: SSLMode("prefer"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := SSLModeDisable
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestFormatter_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected Formatter
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "string typed supported formatter produces no error and gets set",
+			input:    "goimports",
+			expected: FormatterGoimports,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty formatter produces no error and gets default",
+			input:    "",
+			expected: FormatterGofmt,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed unsupported formatter produces error and invalid formatter",
+			input:    "gofumpt",
+			expected: Formatter("gofumpt"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := FormatterGofmt
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestTimestamp_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected Timestamp
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "timestamp in the format of RFC 3339 produces no error and gets set",
+			input:    "2024-01-02T15:04:05+01:00",
+			expected: Timestamp{Time: time.Date(2024, 1, 2, 15, 4, 5, 0, time.FixedZone("", 3600))},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "date only produces error and keeps the zero value",
+			input:    "2024-01-02",
+			expected: Timestamp{},
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var actual Timestamp
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.True(t, test.expected.Equal(actual.Time))
+		})
+	}
+}
+
 func TestSprintfSupportedDbTypes(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -459,3 +1544,82 @@ func TestSprintfSupportedNullTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestSettings_MappedPackage(t *testing.T) {
+	s := New()
+	s.PackageMap = Map{"users": "auth", "audit.users": "audit"}
+
+	tests := []struct {
+		desc     string
+		schema   string
+		table    string
+		expected string
+		ok       bool
+	}{
+		{desc: "table name", schema: "public", table: "users", expected: "auth", ok: true},
+		{desc: "qualified name takes precedence", schema: "audit", table: "users", expected: "audit", ok: true},
+		{desc: "table without schema", schema: "", table: "users", expected: "auth", ok: true},
+		{desc: "unmapped table", schema: "public", table: "orders", expected: "", ok: false},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual, ok := s.MappedPackage(test.schema, test.table)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
+
+func TestSettings_HeaderString(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func(s *Settings)
+		expected string
+	}{
+		{
+			desc:     "header without the version",
+			settings: func(s *Settings) {},
+			expected: "Code generated by tables-to-go; DO NOT EDIT.",
+		},
+		{
+			desc: "version gets appended to the header",
+			settings: func(s *Settings) {
+				s.HeaderVersion = true
+				s.ToolVersion = "v1.2.3"
+			},
+			expected: "Code generated by tables-to-go; DO NOT EDIT.\nGenerated by tables-to-go v1.2.3.",
+		},
+		{
+			desc: "version makes up the empty header",
+			settings: func(s *Settings) {
+				s.Header = ""
+				s.HeaderVersion = true
+				s.ToolVersion = "v1.2.3"
+			},
+			expected: "Generated by tables-to-go v1.2.3.",
+		},
+		{
+			desc: "unknown version is left out",
+			settings: func(s *Settings) {
+				s.Header = ""
+				s.HeaderVersion = true
+			},
+			expected: "Generated by tables-to-go.",
+		},
+		{
+			desc: "empty header without the version is omitted",
+			settings: func(s *Settings) {
+				s.Header = ""
+				s.ToolVersion = "v1.2.3"
+			},
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			test.settings(s)
+			assert.Equal(t, test.expected, s.HeaderString())
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestBun_GenerateTag(t *testing.T) {
+	type test struct {
+		desc     string
+		settings func() *settings.Settings
+		column   database.Column
+		expected string
+	}
+
+	tests := map[settings.DBType][]test{
+		settings.DBTypePostgresql: {
+			{
+				desc: "non PK column generates standard bun-tag",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypePostgresql
+					s.TagsNoDb = true
+					s.TagsBun = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+				},
+				expected: `bun:"column_name"`,
+			},
+			{
+				desc: "PK column generates bun-tag with PK indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypePostgresql
+					s.TagsNoDb = true
+					s.TagsBun = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+				},
+				expected: `bun:"column_name,pk"`,
+			},
+			{
+				desc: "PK and AI column generates bun-tag with PK and AI indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypePostgresql
+					s.TagsNoDb = true
+					s.TagsBun = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+					DefaultValue: sql.NullString{
+						String: "nextval",
+						Valid:  true,
+					},
+				},
+				expected: `bun:"column_name,pk,autoincrement"`,
+			},
+		},
+		settings.DBTypeMySQL: {
+			{
+				desc: "non PK column generates standard bun-tag",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeMySQL
+					s.TagsNoDb = true
+					s.TagsBun = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+				},
+				expected: `bun:"column_name"`,
+			},
+			{
+				desc: "PK column generates bun-tag with PK indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeMySQL
+					s.TagsNoDb = true
+					s.TagsBun = true
+					return s
+				},
+				column: database.Column{
+					Name:      "column_name",
+					ColumnKey: "PRI",
+				},
+				expected: `bun:"column_name,pk"`,
+			},
+			{
+				desc: "PK and AI column generates bun-tag with PK and AI indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeMySQL
+					s.TagsNoDb = true
+					s.TagsBun = true
+					return s
+				},
+				column: database.Column{
+					Name:      "column_name",
+					ColumnKey: "PRI",
+					Extra:     "auto_increment",
+				},
+				expected: `bun:"column_name,pk,autoincrement"`,
+			},
+		},
+		settings.DBTypeSQLite: {
+			{
+				desc: "non PK column generates standard bun-tag",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeSQLite
+					s.TagsNoDb = true
+					s.TagsBun = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+				},
+				expected: `bun:"column_name"`,
+			},
+			{
+				desc: "PK column generates bun-tag with PK indicator and AI indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeSQLite
+					s.TagsNoDb = true
+					s.TagsBun = true
+					return s
+				},
+				column: database.Column{
+					Name:      "column_name",
+					DataType:  "integer",
+					ColumnKey: "PK",
+				},
+				expected: `bun:"column_name,pk,autoincrement"`,
+			},
+		},
+	}
+
+	tagger := new(Bun)
+
+	for dbType := range settings.SupportedDbTypes {
+		t.Run(dbType.String(), func(t *testing.T) {
+			tests := tests[dbType]
+			for _, test := range tests {
+				t.Run(test.desc, func(t *testing.T) {
+					db := database.New(test.settings())
+					actual := tagger.GenerateTag(db, test.column)
+					assert.Equal(t, test.expected, actual)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package tagger
+
+import (
+	"github.com/fraenky8/tables-to-go/pkg/database"
+)
+
+// Gorm represents the GORM "gorm"-tag.
+type Gorm struct{}
+
+// GenerateTag for Gorm to satisfy the Tagger interface.
+func (t Gorm) GenerateTag(db database.Database, column database.Column) string {
+
+	isPk := ""
+	if db.IsPrimaryKey(column) {
+		isPk = ";primaryKey"
+	}
+
+	isAutoIncrement := ""
+	if db.IsAutoIncrement(column) {
+		isAutoIncrement = ";autoIncrement"
+	}
+
+	return `gorm:"column:` + column.Name + isPk + isAutoIncrement + `"`
+}
@@ -0,0 +1,173 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestGorm_GenerateTag(t *testing.T) {
+	type test struct {
+		desc     string
+		settings func() *settings.Settings
+		column   database.Column
+		expected string
+	}
+
+	tests := map[settings.DBType][]test{
+		settings.DBTypePostgresql: {
+			{
+				desc: "non PK column generates standard GORM-tag",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypePostgresql
+					s.TagsNoDb = true
+					s.TagsGorm = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+				},
+				expected: `gorm:"column:column_name"`,
+			},
+			{
+				desc: "PK column generates GORM-tag with PK indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypePostgresql
+					s.TagsNoDb = true
+					s.TagsGorm = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:column_name;primaryKey"`,
+			},
+			{
+				desc: "PK and AI column generates GORM-tag with PK and AI indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypePostgresql
+					s.TagsNoDb = true
+					s.TagsGorm = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+					DefaultValue: sql.NullString{
+						String: "nextval",
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:column_name;primaryKey;autoIncrement"`,
+			},
+		},
+		settings.DBTypeMySQL: {
+			{
+				desc: "non PK column generates standard GORM-tag",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeMySQL
+					s.TagsNoDb = true
+					s.TagsGorm = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+				},
+				expected: `gorm:"column:column_name"`,
+			},
+			{
+				desc: "PK column generates GORM-tag with PK indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeMySQL
+					s.TagsNoDb = true
+					s.TagsGorm = true
+					return s
+				},
+				column: database.Column{
+					Name:      "column_name",
+					ColumnKey: "PRI",
+				},
+				expected: `gorm:"column:column_name;primaryKey"`,
+			},
+			{
+				desc: "PK and AI column generates GORM-tag with PK and AI indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeMySQL
+					s.TagsNoDb = true
+					s.TagsGorm = true
+					return s
+				},
+				column: database.Column{
+					Name:      "column_name",
+					ColumnKey: "PRI",
+					Extra:     "auto_increment",
+				},
+				expected: `gorm:"column:column_name;primaryKey;autoIncrement"`,
+			},
+		},
+		settings.DBTypeSQLite: {
+			{
+				desc: "non PK column generates standard GORM-tag",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeSQLite
+					s.TagsNoDb = true
+					s.TagsGorm = true
+					return s
+				},
+				column: database.Column{
+					Name: "column_name",
+				},
+				expected: `gorm:"column:column_name"`,
+			},
+			{
+				desc: "PK column generates GORM-tag with PK indicator and AI indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeSQLite
+					s.TagsNoDb = true
+					s.TagsGorm = true
+					return s
+				},
+				column: database.Column{
+					Name:      "column_name",
+					DataType:  "integer",
+					ColumnKey: "PK",
+				},
+				expected: `gorm:"column:column_name;primaryKey;autoIncrement"`,
+			},
+		},
+	}
+
+	tagger := new(Gorm)
+
+	for dbType := range settings.SupportedDbTypes {
+		t.Run(dbType.String(), func(t *testing.T) {
+			tests := tests[dbType]
+			for _, test := range tests {
+				t.Run(test.desc, func(t *testing.T) {
+					db := database.New(test.settings())
+					actual := tagger.GenerateTag(db, test.column)
+					assert.Equal(t, test.expected, actual)
+				})
+			}
+		})
+	}
+}
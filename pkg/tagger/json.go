@@ -0,0 +1,21 @@
+package tagger
+
+import (
+	"github.com/fraenky8/tables-to-go/pkg/database"
+)
+
+// JSON represents the encoding/json "json"-tag.
+type JSON struct {
+	OmitEmpty bool
+}
+
+// GenerateTag for JSON to satisfy the Tagger interface.
+func (t JSON) GenerateTag(db database.Database, column database.Column) string {
+
+	omitEmpty := ""
+	if t.OmitEmpty && db.IsNullable(column) {
+		omitEmpty = ",omitempty"
+	}
+
+	return `json:"` + column.Name + omitEmpty + `"`
+}
@@ -0,0 +1,56 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestJSON_GenerateTag(t *testing.T) {
+	tests := []struct {
+		desc      string
+		omitEmpty bool
+		column    database.Column
+		expected  string
+	}{
+		{
+			desc: "column generates standard JSON-tag",
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: `json:"column_name"`,
+		},
+		{
+			desc:      "nullable column with omitempty generates JSON-tag with omitempty",
+			omitEmpty: true,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: `json:"column_name,omitempty"`,
+		},
+		{
+			desc:      "not nullable column with omitempty generates standard JSON-tag",
+			omitEmpty: true,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "NO",
+			},
+			expected: `json:"column_name"`,
+		},
+	}
+
+	db := database.New(settings.New())
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tagger := JSON{OmitEmpty: test.omitEmpty}
+			actual := tagger.GenerateTag(db, test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package tagger
+
+import (
+	"github.com/fraenky8/tables-to-go/pkg/database"
+)
+
+// Mapstructure represents the mitchellh/mapstructure "mapstructure"-tag, eg.
+// as used by Viper to decode configs.
+type Mapstructure struct{}
+
+// GenerateTag for Mapstructure to satisfy the Tagger interface.
+func (t Mapstructure) GenerateTag(db database.Database, column database.Column) string {
+	return `mapstructure:"` + column.Name + `"`
+}
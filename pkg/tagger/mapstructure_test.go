@@ -0,0 +1,36 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestMapstructure_GenerateTag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		column   database.Column
+		expected string
+	}{
+		{
+			desc: "column generates mapstructure-tag with the original column name",
+			column: database.Column{
+				Name: "Column_Name",
+			},
+			expected: `mapstructure:"Column_Name"`,
+		},
+	}
+
+	db := database.New(settings.New())
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tagger := Mapstructure{}
+			actual := tagger.GenerateTag(db, test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -148,6 +148,7 @@ func TestMastermind_GenerateTag(t *testing.T) {
 				},
 				column: database.Column{
 					Name:      "column_name",
+					DataType:  "integer",
 					ColumnKey: "PK",
 				},
 				expected: `stbl:"column_name,PRIMARY_KEY,SERIAL,AUTO_INCREMENT"`,
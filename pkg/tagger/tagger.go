@@ -12,8 +12,14 @@ const (
 	tagsDisabled = 0
 
 	// number is an ascending sequence of i*2 to determine which tags to generate later
-	tagDb         = 1
-	tagMastermind = 2
+	tagDb           = 1
+	tagMastermind   = 2
+	tagGorm         = 4
+	tagTemplate     = 8
+	tagJSON         = 16
+	tagBun          = 32
+	tagMapstructure = 64
+	tagValidate     = 128
 )
 
 var stringPool = sync.Pool{
@@ -41,8 +47,14 @@ func NewTaggers(s *settings.Settings) *Taggers {
 		settings:    s,
 		enabledTags: tagDb,
 		taggers: map[int]Tagger{
-			tagDb:         new(Db),
-			tagMastermind: new(Mastermind),
+			tagDb:           new(Db),
+			tagMastermind:   new(Mastermind),
+			tagGorm:         new(Gorm),
+			tagTemplate:     NewTemplate(s.TagTemplate),
+			tagJSON:         &JSON{OmitEmpty: s.OmitEmpty},
+			tagBun:          new(Bun),
+			tagMapstructure: new(Mapstructure),
+			tagValidate:     new(Validate),
 		},
 	}
 
@@ -61,6 +73,24 @@ func (t *Taggers) enableTags() {
 	if t.settings.TagsMastermindStructable {
 		t.enabledTags |= tagMastermind
 	}
+	if t.settings.TagsGorm {
+		t.enabledTags |= tagGorm
+	}
+	if t.settings.TagsJSON {
+		t.enabledTags |= tagJSON
+	}
+	if t.settings.TagsBun {
+		t.enabledTags |= tagBun
+	}
+	if t.settings.TagsMapstructure {
+		t.enabledTags |= tagMapstructure
+	}
+	if t.settings.TagsValidate {
+		t.enabledTags |= tagValidate
+	}
+	if t.settings.TagTemplate != "" {
+		t.enabledTags |= tagTemplate
+	}
 	if t.settings.TagsMastermindStructableOnly {
 		t.enabledTags = tagsDisabled
 		t.enabledTags |= tagMastermind
@@ -76,18 +106,25 @@ func (t *Taggers) GenerateTag(db database.Database, column database.Column) (tag
 		stringPool.Put(sb)
 	}()
 
+	// the order of the tags is given by their bits, not by the order of the
+	// flags; tags which turn out empty, eg. by a template, are skipped
 	for bit := 1; bit <= t.enabledTags; bit *= 2 {
 		shouldTag := t.enabledTags&bit > 0
-		if shouldTag {
-			sb.WriteString(t.taggers[bit].GenerateTag(db, column))
+		if !shouldTag {
+			continue
+		}
+		tag := strings.TrimSpace(t.taggers[bit].GenerateTag(db, column))
+		if tag == "" {
+			continue
+		}
+		if sb.Len() > 0 {
 			sb.WriteString(" ")
 		}
+		sb.WriteString(tag)
 	}
 
-	tags = sb.String()
-
-	if len(tags) > 0 {
-		tags = "`" + strings.TrimSpace(tags) + "`"
+	if sb.Len() > 0 {
+		tags = "`" + sb.String() + "`"
 	}
 
 	return tags
@@ -38,6 +38,32 @@ func TestTaggers_GenerateTags(t *testing.T) {
 			column:   database.Column{},
 			expected: "",
 		},
+		{
+			desc: "default db-tag with enabled JSON-tag and omitempty creates db- and JSON-tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.OmitEmpty = true
+				return s
+			},
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: "`db:\"column_name\" json:\"column_name,omitempty\"`",
+		},
+		{
+			desc: "default db-tag with tag template creates db- and custom tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagTemplate = `pg:"{{.ColumnName}}"`
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`db:\"column_name\" pg:\"column_name\"`",
+		},
 		{
 			desc: "default db-tag with enabled Mastermind-tag creates db- and Mastermind-tags",
 			settings: func() *settings.Settings {
@@ -77,6 +103,86 @@ func TestTaggers_GenerateTags(t *testing.T) {
 			},
 			expected: "`stbl:\"column_name\"`",
 		},
+		{
+			desc: "default db-tag with enabled GORM-tag creates db- and GORM-tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsGorm = true
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`db:\"column_name\" gorm:\"column:column_name\"`",
+		},
+		{
+			desc: "disabled db-tag with enabled Mastermind- and GORM-tag creates Mastermind- and GORM-tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNoDb = true
+				s.TagsMastermindStructable = true
+				s.TagsGorm = true
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`stbl:\"column_name\" gorm:\"column:column_name\"`",
+		},
+		{
+			desc: "disabled db-tag with empty tag template generates no tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNoDb = true
+				s.TagTemplate = `{{if .IsPrimaryKey}}pk:"true"{{end}}`
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "",
+		},
+		{
+			desc: "default db-tag with enabled JSON- and mapstructure-tag creates all of them in one tag block",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsMapstructure = true
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`db:\"column_name\" json:\"column_name\" mapstructure:\"column_name\"`",
+		},
+		{
+			desc: "default db-tag with enabled JSON- and validate-tag creates all of them in one tag block",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsValidate = true
+				return s
+			},
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "NO",
+				GoType:     "string",
+			},
+			expected: "`db:\"column_name\" json:\"column_name\" validate:\"required\"`",
+		},
+		{
+			desc: "empty tag template between other tags leaves no extra space",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagTemplate = `{{if .IsPrimaryKey}}pk:"true"{{end}}`
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`db:\"column_name\" json:\"column_name\"`",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
@@ -0,0 +1,51 @@
+package tagger
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// TemplateData is the data a tag template gets executed with per column.
+type TemplateData = settings.TagTemplateData
+
+// Template represents a custom tag rendered by a text/template.
+type Template struct {
+	template *template.Template
+}
+
+// NewTemplate creates the tagger of the given template text. The template is
+// expected to be parsed and executed by the verification of the settings
+// already, an invalid template renders no tag.
+func NewTemplate(text string) *Template {
+	tmpl, err := template.New("tag").Parse(text)
+	if err != nil {
+		return &Template{}
+	}
+	return &Template{template: tmpl}
+}
+
+// GenerateTag for Template to satisfy the Tagger interface.
+func (t Template) GenerateTag(db database.Database, column database.Column) string {
+	if t.template == nil {
+		return ""
+	}
+
+	var tag strings.Builder
+
+	err := t.template.Execute(&tag, TemplateData{
+		ColumnName:      column.Name,
+		DataType:        column.DataType,
+		DefaultValue:    column.DefaultValue.String,
+		IsNullable:      db.IsNullable(column),
+		IsPrimaryKey:    db.IsPrimaryKey(column),
+		IsAutoIncrement: db.IsAutoIncrement(column),
+	})
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(tag.String())
+}
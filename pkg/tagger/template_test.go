@@ -0,0 +1,68 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestTemplate_GenerateTag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		template string
+		column   database.Column
+		expected string
+	}{
+		{
+			desc:     "template gets rendered with the column name",
+			template: `pg:"{{.ColumnName}}"`,
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: `pg:"column_name"`,
+		},
+		{
+			desc:     "template gets rendered with the column properties",
+			template: `pg:"{{.ColumnName}}{{if .IsPrimaryKey}},pk{{end}}{{if .IsNullable}},null{{end}}" type:"{{.DataType}}"`,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "integer",
+				IsNullable: "YES",
+				ConstraintType: sql.NullString{
+					String: "PRIMARY KEY",
+					Valid:  true,
+				},
+			},
+			expected: `pg:"column_name,pk,null" type:"integer"`,
+		},
+		{
+			desc:     "invalid template renders no tag",
+			template: `pg:"{{.ColumnName"`,
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "",
+		},
+		{
+			desc:     "unknown field renders no tag",
+			template: `pg:"{{.Unknown}}"`,
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "",
+		},
+	}
+
+	db := database.New(settings.New())
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := NewTemplate(test.template).GenerateTag(db, test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
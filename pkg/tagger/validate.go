@@ -0,0 +1,56 @@
+package tagger
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+)
+
+// Validate represents the go-playground/validator "validate"-tag derived from
+// the column. NOT NULL columns of strings, pointers and slices are required
+// unless the database fills them, ie. auto increment columns and columns with
+// a default value. The zero values of the other types, eg. false or 0, are
+// valid values of NOT NULL columns. The length of fields of type string or
+// *string is limited by the maximum length of their column, if any; the
+// validator does not look into other types, eg. sql.NullString.
+type Validate struct{}
+
+// GenerateTag for Validate to satisfy the Tagger interface.
+func (t Validate) GenerateTag(db database.Database, column database.Column) string {
+
+	var rules []string
+
+	isNullable := db.IsNullable(column)
+	if !isNullable && isRequirable(column.GoType) && !db.IsAutoIncrement(column) && !column.DefaultValue.Valid {
+		rules = append(rules, "required")
+	}
+
+	isString := (db.IsString(column) || db.IsText(column)) && isLimitable(column.GoType)
+	if isString && column.CharacterMaximumLength.Valid && column.CharacterMaximumLength.Int64 > 0 {
+		// nil pointers and empty strings of nullable columns are valid
+		if isNullable {
+			rules = append(rules, "omitempty")
+		}
+		rules = append(rules, "max="+strconv.FormatInt(column.CharacterMaximumLength.Int64, 10))
+	}
+
+	if len(rules) == 0 {
+		return ""
+	}
+
+	return `validate:"` + strings.Join(rules, ",") + `"`
+}
+
+// isRequirable returns true if the zero value of the given type of a field is
+// no valid value of a NOT NULL column, ie. the empty string, nil pointers and
+// nil slices.
+func isRequirable(goType string) bool {
+	return goType == "string" || strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]")
+}
+
+// isLimitable returns true if the length of the given type of a field can be
+// limited by the validator, ie. of strings and pointers to them.
+func isLimitable(goType string) bool {
+	return goType == "string" || goType == "*string"
+}
@@ -0,0 +1,176 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestValidate_GenerateTag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		column   database.Column
+		expected string
+	}{
+		{
+			desc: "NOT NULL string column is required",
+			column: database.Column{
+				Name:       "name",
+				DataType:   "text",
+				IsNullable: "NO",
+				GoType:     "string",
+			},
+			expected: `validate:"required"`,
+		},
+		{
+			desc: "NOT NULL slice column is required",
+			column: database.Column{
+				Name:       "payload",
+				DataType:   "bytea",
+				IsNullable: "NO",
+				GoType:     "[]byte",
+			},
+			expected: `validate:"required"`,
+		},
+		{
+			desc: "NOT NULL pointer column is required",
+			column: database.Column{
+				Name:       "id",
+				DataType:   "integer",
+				IsNullable: "NO",
+				GoType:     "*UserID",
+			},
+			expected: `validate:"required"`,
+		},
+		{
+			desc: "NOT NULL int column is not required, 0 is valid",
+			column: database.Column{
+				Name:       "age",
+				DataType:   "integer",
+				IsNullable: "NO",
+				GoType:     "int",
+			},
+			expected: "",
+		},
+		{
+			desc: "NOT NULL bool column is not required, false is valid",
+			column: database.Column{
+				Name:       "active",
+				DataType:   "boolean",
+				IsNullable: "NO",
+				GoType:     "bool",
+			},
+			expected: "",
+		},
+		{
+			desc: "NOT NULL column with maximum length is required and limited",
+			column: database.Column{
+				Name:                   "email",
+				DataType:               "character varying",
+				IsNullable:             "NO",
+				CharacterMaximumLength: sql.NullInt64{Int64: 255, Valid: true},
+				GoType:                 "string",
+			},
+			expected: `validate:"required,max=255"`,
+		},
+		{
+			desc: "nullable pointer column with maximum length is limited if given",
+			column: database.Column{
+				Name:                   "nickname",
+				DataType:               "character varying",
+				IsNullable:             "YES",
+				CharacterMaximumLength: sql.NullInt64{Int64: 32, Valid: true},
+				GoType:                 "*string",
+			},
+			expected: `validate:"omitempty,max=32"`,
+		},
+		{
+			desc: "nullable column of null type zero with maximum length is limited if given",
+			column: database.Column{
+				Name:                   "nickname",
+				DataType:               "character varying",
+				IsNullable:             "YES",
+				CharacterMaximumLength: sql.NullInt64{Int64: 32, Valid: true},
+				GoType:                 "string",
+			},
+			expected: `validate:"omitempty,max=32"`,
+		},
+		{
+			desc: "nullable column of the default null type sql is not limited",
+			column: database.Column{
+				Name:                   "nickname",
+				DataType:               "character varying",
+				IsNullable:             "YES",
+				CharacterMaximumLength: sql.NullInt64{Int64: 32, Valid: true},
+				GoType:                 "sql.NullString",
+			},
+			expected: "",
+		},
+		{
+			desc: "NOT NULL column of an overridden type is not limited",
+			column: database.Column{
+				Name:                   "email",
+				DataType:               "character varying",
+				IsNullable:             "NO",
+				CharacterMaximumLength: sql.NullInt64{Int64: 255, Valid: true},
+				GoType:                 "Email",
+			},
+			expected: "",
+		},
+		{
+			desc: "nullable column without maximum length generates no tag",
+			column: database.Column{
+				Name:       "bio",
+				DataType:   "text",
+				IsNullable: "YES",
+			},
+			expected: "",
+		},
+		{
+			desc: "auto increment column is not required",
+			column: database.Column{
+				Name:         "id",
+				DataType:     "character varying",
+				IsNullable:   "NO",
+				DefaultValue: sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+				GoType:       "string",
+			},
+			expected: "",
+		},
+		{
+			desc: "column with default value is not required",
+			column: database.Column{
+				Name:         "status",
+				DataType:     "text",
+				IsNullable:   "NO",
+				DefaultValue: sql.NullString{String: "'active'::text", Valid: true},
+				GoType:       "string",
+			},
+			expected: "",
+		},
+		{
+			desc: "length of bit columns is no maximum length of a string",
+			column: database.Column{
+				Name:                   "flags",
+				DataType:               "bit",
+				IsNullable:             "YES",
+				CharacterMaximumLength: sql.NullInt64{Int64: 8, Valid: true},
+			},
+			expected: "",
+		},
+	}
+
+	db := database.New(settings.New())
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tagger := Validate{}
+			actual := tagger.GenerateTag(db, test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
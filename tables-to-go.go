@@ -4,16 +4,28 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"runtime/debug"
 
-	"github.com/fraenky8/tables-to-go/internal/cli"
-	"github.com/fraenky8/tables-to-go/pkg/database"
-	"github.com/fraenky8/tables-to-go/pkg/output"
+	"github.com/fraenky8/tables-to-go/pkg/generator"
 	"github.com/fraenky8/tables-to-go/pkg/settings"
 )
 
+// These environment variables are used as fallback for their flags.
+const (
+	envUser     = "TABLESTOGO_USER"
+	envPassword = "TABLESTOGO_PASSWORD"
+	envHost     = "TABLESTOGO_HOST"
+)
+
+// version is the version of the tool, injected at build time, eg. by
+// `go build -ldflags "-X main.version=v1.2.3"`.
+var version = "dev"
+
 // CmdArgs represents the supported command line args
 type CmdArgs struct {
-	Help bool
+	Help       bool
+	Version    bool
+	ConfigFile string
 	*settings.Settings
 }
 
@@ -26,36 +38,125 @@ func NewCmdArgs() (args *CmdArgs) {
 
 	flag.BoolVar(&args.Help, "?", false, "shows help and usage")
 	flag.BoolVar(&args.Help, "help", false, "shows help and usage")
+	flag.BoolVar(&args.Version, "version", false, "prints the version, build commit and date and exits")
+	flag.StringVar(&args.ConfigFile, "config", args.ConfigFile, "path to a YAML file to load the settings from, explicitly set flags take precedence")
 	flag.BoolVar(&args.Verbose, "v", args.Verbose, "verbose output")
 	flag.BoolVar(&args.VVerbose, "vv", args.VVerbose, "more verbose output")
+	flag.BoolVar(&args.Quiet, "quiet", args.Quiet, "no output except errors, takes precedence over -v and -vv")
 	flag.BoolVar(&args.Force, "f", args.Force, "force; skip tables that encounter errors")
+	flag.BoolVar(&args.FailOnEmpty, "fail-on-empty", args.FailOnEmpty, "exit with an error instead of a warning if no tables are found, eg. due to a typo in -d or -s")
+	flag.IntVar(&args.Concurrency, "concurrency", args.Concurrency, "number of tables to process concurrently, defaults to the number of CPUs")
 
 	flag.Var(&args.DbType, "t", fmt.Sprintf("type of database to use, currently supported: %v", settings.SprintfSupportedDbTypes()))
-	flag.StringVar(&args.User, "u", args.User, "user to connect to the database")
-	flag.StringVar(&args.Pswd, "p", args.Pswd, "password of user")
-	flag.StringVar(&args.DbName, "d", args.DbName, "database name")
-	flag.StringVar(&args.Schema, "s", args.Schema, "schema name")
-	flag.StringVar(&args.Host, "h", args.Host, "host of database")
+	flag.StringVar(&args.User, "u", args.User, "user to connect to the database; resolved by flag, then env "+envUser+", then config file")
+	flag.StringVar(&args.Pswd, "p", args.Pswd, "password of user; resolved by flag, then env "+envPassword+", then config file")
+	flag.StringVar(&args.DbName, "d", args.DbName, "database name, for MySQL a comma separated list of databases is supported, eg. \"app,audit\"")
+	flag.StringVar(&args.Schema, "s", args.Schema, "schema name or comma separated list of schema names, eg. \"public,audit\"")
+	flag.BoolVar(&args.BatchColumns, "batch-columns", args.BatchColumns, "get the columns of all tables in a single query instead of one query per table, faster for many tables over slow connections")
+	flag.Var(&args.Include, "include", "comma separated glob patterns of the tables to generate structs for, eg. \"user_*,order_*\"")
+	flag.Var(&args.Exclude, "exclude", "comma separated glob patterns of the tables to skip, takes precedence over -include")
+	flag.BoolVar(&args.CITables, "ci-tables", args.CITables, "match the names of the tables case-insensitively against -include and -exclude, eg. \"useraccounts\" matches the table UserAccounts")
+	flag.BoolVar(&args.Views, "views", args.Views, "generate structs for views as well")
+	flag.BoolVar(&args.Routines, "routines", args.Routines, "generate structs <Function>Result for the results of functions returning sets of rows (PostgreSQL only, -t pg; not Redshift or CockroachDB)")
+	flag.StringVar(&args.Host, "h", args.Host, "host of database; resolved by flag, then env "+envHost+", then config file")
 	flag.StringVar(&args.Port, "port", args.Port, "port of database host, if not specified, it will be the default ports for the supported databases")
+	flag.Var(&args.SSL, "ssl", fmt.Sprintf("SSL/TLS mode of the connection to the database: %v", settings.SprintfSupportedSSLModes()))
+	flag.DurationVar(&args.Timeout, "timeout", args.Timeout, "timeout of connecting to the database including all retries, and of querying the tables afterwards, eg. 30s; 0 means no timeout")
+	flag.IntVar(&args.Retries, "retries", args.Retries, "number of retries with an increasing backoff if connecting to the database fails")
+	flag.IntVar(&args.MaxOpenConns, "max-open-conns", args.MaxOpenConns, "maximum number of open connections to the database; 0 means no limit")
+	flag.IntVar(&args.MaxIdleConns, "max-idle-conns", args.MaxIdleConns, "maximum number of idle connections to the database; 0 means none are kept")
+	flag.DurationVar(&args.ConnMaxLifetime, "conn-max-lifetime", args.ConnMaxLifetime, "maximum amount of time a connection to the database may be reused, eg. 5m; 0 means no limit")
+	flag.StringVar(&args.DataSourceName, "dsn", args.DataSourceName, "data source name passed as is to the driver of the database type (-t), takes precedence over the other connection flags; -d and -s still select the tables")
+	flag.StringVar(&args.SchemaFile, "schema-file", args.SchemaFile, "JSON file describing the tables to read instead of connecting to the database, the datatypes are the ones of the database type (-t); see README")
 	flag.StringVar(&args.Socket, "socket", args.Socket, "The socket file to use for connection. If specified, takes precedence over host:port.")
 
 	flag.StringVar(&args.OutputFilePath, "of", args.OutputFilePath, "output file path, default is current working directory")
 	flag.Var(&args.OutputFormat, "format", "format of struct fields (columns): camelCase (c) or original (o)")
+	flag.BoolVar(&args.NoFormat, "noformat", args.NoFormat, "do not format the output with gofmt, only indent it by -indent")
+	flag.Var(&args.Formatter, "formatter", fmt.Sprintf("formatter of the output: %v; goimports sorts and groups the imports as well, falls back to gofmt if not found on the PATH", settings.SprintfSupportedFormatters()))
+	flag.BoolVar(&args.VerifyOutput, "verify", args.VerifyOutput, "type check the packages of the files written and report compilation errors")
+	flag.StringVar(&args.Indent, "indent", args.Indent, "indentation of the output if not formatted (-noformat), eg. 4 spaces or \\t for tabs")
+	flag.BoolVar(&args.SingleFile, "singlefile", args.SingleFile, "write the structs of all tables into one single file named after the package")
+	flag.BoolVar(&args.Append, "append", args.Append, "append the structs of new tables to the existing file of -singlefile instead of overwriting it, structs already declared in the file are skipped, eg. to keep a hand-extended file")
+	flag.BoolVar(&args.OutputStdout, "output-stdout", args.OutputStdout, "write the structs of all tables merged like -singlefile to stdout instead of the output file path, eg. to redirect them into a file; the progress goes to stderr")
+	flag.Var(&args.Since, "since", "only write the files of the tables whose columns or foreign keys changed since the given RFC 3339 timestamp, eg. 2024-01-02T15:04:05Z, along with the other files changed since then; the changes are tracked by hashes in the file .tables-to-go-snapshot.json of the output file path, a change of the settings changes all files")
+	flag.BoolVar(&args.NoOverwrite, "no-overwrite", args.NoOverwrite, "skip the tables whose file already exists instead of overwriting it, eg. to keep customized files")
+	flag.BoolVar(&args.SubDirs, "subdirs", args.SubDirs, "write the file of each table into a sub directory named after its schema")
 
-	flag.Var(&args.FileNameFormat, "fn-format", "format of the filename: camelCase (c, default) or snake_case (s)")
+	flag.Var(&args.FileNameFormat, "fn-format", "format of the filename, independent of the struct names: camelCase (c, camel, default), snake_case (s, snake) or the original name of the table (o, original)")
+	flag.Var(&args.FileNameFormat, "filename-format", "same as -fn-format")
 	flag.StringVar(&args.Prefix, "pre", args.Prefix, "prefix for file- and struct names")
 	flag.StringVar(&args.Suffix, "suf", args.Suffix, "suffix for file- and struct names")
-	flag.StringVar(&args.PackageName, "pn", args.PackageName, "package name")
-	flag.Var(&args.Null, "null", "representation of NULL columns: sql.Null* (sql) or primitive pointers (native|primitive)")
+	flag.StringVar(&args.TrimPrefix, "trim-prefix", args.TrimPrefix, "prefix trimmed from the table names before deriving the file- and struct names, eg. \"tbl_\"; the names of the tables themselves are kept")
+	flag.StringVar(&args.PackageName, "pn", "", "package name, defaults to the name of the output file path (-of) in lower case without invalid characters")
+	flag.StringVar(&args.BuildTags, "buildtags", args.BuildTags, "build constraint to put in front of the package clause of the generated files, eg. \"integration && !windows\"")
+	flag.StringVar(&args.Header, "header", args.Header, "comment to put in front of the package clause of the generated files, an empty header omits it")
+	flag.BoolVar(&args.HeaderVersion, "header-version", args.HeaderVersion, "append the version of tables-to-go to the header of the generated files, or make it up if -header is empty")
+	flag.BoolVar(&args.ScanHelper, "scanhelper", args.ScanHelper, "generate a ScanDest() method per struct returning pointers to its fields in the order of the columns, eg. for rows.Scan(u.ScanDest()...); structs with fields of validity of -null zero get ScanRow() instead")
+	flag.BoolVar(&args.Fixtures, "fixtures", args.Fixtures, "generate a function Sample<Struct>() per struct returning it populated with sample values by the types of its fields, eg. empty strings, 0, false and time.Now(), as a starting point of test fixtures")
+	flag.BoolVar(&args.Constructor, "constructor", args.Constructor, "generate a constructor New<Struct>() per struct initializing the fields with the literal defaults of their non-nullable columns")
+	flag.BoolVar(&args.ColumnMap, "columnmap", args.ColumnMap, "generate a variable <Struct>Columns per struct holding the column name of each field, eg. UsersColumns.ID")
+	flag.BoolVar(&args.SQL, "sql", args.SQL, "generate constants per struct holding the statements to select all rows, to insert a row and to update a row by its primary key, eg. UsersSelectAll")
+	flag.BoolVar(&args.Ent, "ent", args.Ent, "generate ent schemas, eg. func (Users) Fields() []ent.Field, instead of structs; -pn should be the package of the schemas, eg. schema")
+	flag.BoolVar(&args.Getters, "getters", args.Getters, "generate a getter method per field, eg. GetID(), and an interface <Struct>Getter grouping them")
+	flag.BoolVar(&args.Registry, "registry", args.Registry, "generate a variable AllTables holding the names of all tables in a separate file per package, eg. for iterating over the schema in migration tools")
+	flag.BoolVar(&args.FieldMeta, "fieldmeta", args.FieldMeta, "generate a method Fields() per struct returning the name, column and type of each field in the order of the columns, along with the shared type FieldMeta")
+	flag.Var(&args.TableConst, "tableconst", "generate the name of the table along with each struct: as method TableName() (method) or as constant TableName<Struct> (const)")
+	flag.Var(&args.Null, "null", "representation of NULL columns: sql.Null* (sql), primitive pointers (native|primitive|pointers|pointer) or zero values along with a field <Field>Valid bool (zero); "+
+		"sql.Null* and pointers scan NULL as is, zero values get scanned by a generated ScanRow() method setting <Field>Valid, but keep the fields free of nil checks, eg. for protobuf messages")
+	flag.Var(&args.Null, "null-strategy", "same as -null")
 
 	flag.BoolVar(&args.NoInitialism, "no-initialism", args.NoInitialism, "disable the conversion to upper-case words in column names")
+	flag.BoolVar(&args.NoInitialism, "lower-initialisms", args.NoInitialism, "same as -no-initialism")
+	flag.BoolVar(&args.Initialisms, "initialisms", args.Initialisms, "upper-case whole words of column names which are common initialisms known by golint, eg. api_key becomes APIKey, in addition to ID, JSON, XML, HTTP and URL")
+	flag.Var(&args.ExtraInitialisms, "extra-initialisms", "comma separated initialisms upper-cased in column names in addition to the common ones, eg. \"ssn,ein\"; requires -initialisms")
+	flag.BoolVar(&args.Unexported, "unexported", args.Unexported, "lower case the first letter of struct and field names, eg. for domain models; the db-tags are kept but sqlx cannot scan unexported fields")
+	flag.Var(&args.Embed, "embed", "comma separated columns, eg. \"created_at,updated_at\", replaced by an embedded struct named by -embed-name in the tables having all of them of the same type and nullability")
+	flag.StringVar(&args.EmbedName, "embed-name", args.EmbedName, "name of the struct embedded for the columns given by -embed")
+	flag.Var(&args.Rename, "rename", "comma separated names of struct fields overriding the generated ones, eg. \"old_col:NewName,table.col:Other\"; the db-tag keeps the column name")
+	flag.StringVar(&args.IDType, "id-type", args.IDType, "type of integer primary key columns, optionally qualified by the path of its package, eg. ID or github.com/example/graph.ID; an override of the type of the column (-typeoverride) takes precedence")
+	flag.Var(&args.DomainMap, "domain-map", "comma separated go types of the columns of PostgreSQL domains instead of the ones of their base types, eg. \"email:string,money_amount:github.com/shopspring/decimal.Decimal\"")
+	flag.Var(&args.PackageMap, "pkg-map", "comma separated package names of tables overriding -pn, eg. \"users:auth,orders:commerce\"; the structs of a mapped table are written into the sub directory of its package, tables may be qualified by their schema, eg. \"audit.logs:audit\"")
+	flag.Var(&args.TypeOverride, "typeoverride", "comma separated go types of struct fields overriding the mapped ones, eg. \"status:Status,users.ip:github.com/example/types.IP\"; the package of a type qualified by its path gets imported")
+	flag.BoolVar(&args.PKFirst, "pk-first", args.PKFirst, "put the fields of primary key columns first, otherwise the order of the columns is kept")
+
+	flag.BoolVar(&args.Comments, "comments", args.Comments, "document the fields with the comments of their columns in the database, and the distribution and sort keys of Redshift")
+	flag.BoolVar(&args.DDLComment, "ddl-comment", args.DDLComment, "document each struct with a summary of its table similar to the CREATE TABLE statement")
+	flag.BoolVar(&args.Defaults, "defaults", args.Defaults, "annotate the fields of columns with a default value with a trailing comment")
+	flag.BoolVar(&args.ForeignKeys, "fk", args.ForeignKeys, "annotate the fields of foreign key columns with the referenced table and column")
+
+	flag.BoolVar(&args.UUID, "uuid", args.UUID, "map uuid columns to uuid.UUID of github.com/google/uuid instead of string")
+	flag.BoolVar(&args.EnumConsts, "enum-consts", args.EnumConsts, "generate a named string type with a constant for each allowed value of MySQL enum columns")
+	flag.BoolVar(&args.JSONRaw, "json-raw", args.JSONRaw, "map json columns to json.RawMessage of encoding/json instead of string")
+	flag.StringVar(&args.GeomType, "geom-type", args.GeomType, "type of geometry columns, optionally qualified by the path of its package, eg. github.com/paulmach/orb.Geometry")
+	flag.StringVar(&args.IntervalType, "interval-type", args.IntervalType, "type of interval columns, optionally qualified by the path of its package, eg. time.Duration; types other than string have to parse the interval format of the database")
+	flag.BoolVar(&args.TinyIntBool, "tinyint-bool", args.TinyIntBool, "map MySQL tinyint(1) columns to bool instead of int")
+	flag.BoolVar(&args.NetStrings, "net-strings", args.NetStrings, "map network address columns (inet, cidr, macaddr) to string instead of the generated types NetIP, NetIPNet and NetHardwareAddr wrapping the ones of package net")
+	flag.BoolVar(&args.Hstore, "hstore", args.Hstore, "map hstore columns (PostgreSQL) to hstore.Hstore of github.com/lib/pq/hstore instead of string")
+	flag.BoolVar(&args.Decimal, "decimal", args.Decimal, "map decimal and numeric columns, as well as money columns (PostgreSQL), to decimal.Decimal of github.com/shopspring/decimal instead of float64 and string")
+	flag.BoolVar(&args.BlobBytes, "blob-bytes", args.BlobBytes, "map blob columns (MySQL) to []byte instead of string")
 
 	flag.BoolVar(&args.TagsNoDb, "tags-no-db", args.TagsNoDb, "do not create db-tags")
+	flag.BoolVar(&args.TagsNoDb, "no-db-tag", args.TagsNoDb, "same as -tags-no-db")
 
 	flag.BoolVar(&args.TagsMastermindStructable, "tags-structable", args.TagsMastermindStructable, "generate struct with tags for use in Masterminds/structable (https://github.com/Masterminds/structable)")
 	flag.BoolVar(&args.TagsMastermindStructableOnly, "tags-structable-only", args.TagsMastermindStructableOnly, "generate struct with tags ONLY for use in Masterminds/structable (https://github.com/Masterminds/structable)")
 	flag.BoolVar(&args.IsMastermindStructableRecorder, "structable-recorder", args.IsMastermindStructableRecorder, "generate a structable.Recorder field")
 
+	flag.BoolVar(&args.TagsGorm, "tags-gorm", args.TagsGorm, "generate struct with tags for use in GORM (https://gorm.io)")
+	flag.BoolVar(&args.TagsBun, "tags-bun", args.TagsBun, "generate struct with tags and an embedded bun.BaseModel for use in bun (https://bun.uptrace.dev)")
+	flag.BoolVar(&args.TagsBun, "bun", args.TagsBun, "same as -tags-bun")
+
+	flag.BoolVar(&args.TagsJSON, "tags-json", args.TagsJSON, "generate struct with json-tags named after the columns")
+	flag.BoolVar(&args.OmitEmpty, "omitempty", args.OmitEmpty, "add omitempty to the json-tags of nullable columns (-tags-json)")
+
+	flag.BoolVar(&args.TagsMapstructure, "tags-mapstructure", args.TagsMapstructure, "generate struct with mapstructure-tags named after the columns, eg. for configs decoded by Viper")
+	flag.BoolVar(&args.TagsMapstructure, "mapstructure", args.TagsMapstructure, "same as -tags-mapstructure")
+	flag.BoolVar(&args.TagsValidate, "tags-validate", args.TagsValidate, "generate struct with validate-tags of go-playground/validator, required for NOT NULL columns without default and max=N for strings of a maximum length")
+	flag.BoolVar(&args.TagsValidate, "validate", args.TagsValidate, "same as -tags-validate")
+
+	flag.StringVar(&args.TagTemplate, "tagtemplate", args.TagTemplate, "text/template of a custom tag rendered per column, eg. '"+`pg:"{{.ColumnName}}{{if .IsPrimaryKey}},pk{{end}}"`+"'; available: .ColumnName .DataType .DefaultValue .IsNullable .IsPrimaryKey .IsAutoIncrement")
+
 	// disable the print of usage when an error occurs
 	flag.CommandLine.Usage = func() {}
 
@@ -64,6 +165,120 @@ func NewCmdArgs() (args *CmdArgs) {
 	return args
 }
 
+// loadConfigFile loads the settings from the config file, if one was given.
+// Flags explicitly set on the command line take precedence over the values
+// of the config file.
+func (args *CmdArgs) loadConfigFile() error {
+
+	if args.ConfigFile == "" {
+		return nil
+	}
+
+	explicitFlags := map[string]string{}
+	flag.Visit(func(f *flag.Flag) {
+		explicitFlags[f.Name] = f.Value.String()
+	})
+
+	if err := args.LoadConfigFile(args.ConfigFile); err != nil {
+		return err
+	}
+
+	for name, value := range explicitFlags {
+		if err := flag.Set(name, value); err != nil {
+			return fmt.Errorf("could not set flag %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// loadEnvironment uses the environment variables as fallback for the flags
+// not explicitly set on the command line. They take precedence over the
+// values of the config file.
+func (args *CmdArgs) loadEnvironment() {
+
+	explicitFlags := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) {
+		explicitFlags[f.Name] = true
+	})
+
+	for _, fallback := range []struct {
+		flag  string
+		env   string
+		value *string
+	}{
+		{flag: "u", env: envUser, value: &args.User},
+		{flag: "p", env: envPassword, value: &args.Pswd},
+		{flag: "h", env: envHost, value: &args.Host},
+	} {
+		if explicitFlags[fallback.flag] {
+			continue
+		}
+		if value, ok := os.LookupEnv(fallback.env); ok && value != "" {
+			*fallback.value = value
+		}
+	}
+}
+
+// buildInfo returns the information of the build, nil if unknown.
+func buildInfo() *debug.BuildInfo {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	return info
+}
+
+// buildVersion returns the version of the tool. It falls back to the version
+// of the module if not injected, eg. if installed by go install.
+func buildVersion(info *debug.BuildInfo) string {
+	if version != "dev" {
+		return version
+	}
+	if info != nil && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return version
+}
+
+// versionString returns the version along with the commit and date of the
+// build as far as known.
+func versionString(info *debug.BuildInfo) string {
+
+	v := buildVersion(info)
+
+	if info == nil {
+		return v
+	}
+
+	var commit, date, modified string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			commit = setting.Value
+			if len(commit) > 12 {
+				commit = commit[:12]
+			}
+		case "vcs.time":
+			date = setting.Value
+		case "vcs.modified":
+			if setting.Value == "true" {
+				modified = "+dirty"
+			}
+		}
+	}
+
+	if commit != "" {
+		v += " (commit " + commit + modified
+		if date != "" {
+			v += ", built " + date
+		}
+		v += ")"
+	}
+
+	return v
+}
+
 // main function to run the transformations
 func main() {
 
@@ -74,22 +289,28 @@ func main() {
 		os.Exit(0)
 	}
 
-	if err := cmdArgs.Verify(); err != nil {
-		fmt.Print(err)
+	if cmdArgs.Version {
+		fmt.Println("tables-to-go " + versionString(buildInfo()))
+		os.Exit(0)
+	}
+
+	if err := cmdArgs.loadConfigFile(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
-	db := database.New(cmdArgs.Settings)
+	cmdArgs.loadEnvironment()
 
-	if err := db.Connect(); err != nil {
-		fmt.Println(err)
+	// neither given by flag nor by config file
+	if err := cmdArgs.DerivePackageName(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
-	writer := output.NewFileWriter(cmdArgs.OutputFilePath)
+	cmdArgs.ToolVersion = buildVersion(buildInfo())
 
-	if err := cli.Run(cmdArgs.Settings, db, writer); err != nil {
-		fmt.Printf("run error: %v\n", err)
+	if err := generator.Generate(cmdArgs.Settings); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
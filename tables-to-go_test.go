@@ -0,0 +1,88 @@
+package main
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionString(t *testing.T) {
+	tests := []struct {
+		desc     string
+		version  string
+		info     *debug.BuildInfo
+		expected string
+	}{
+		{
+			desc:     "unknown build info keeps the default version",
+			version:  "dev",
+			expected: "dev",
+		},
+		{
+			desc:    "injected version takes precedence over the version of the module",
+			version: "v1.2.3",
+			info: &debug.BuildInfo{
+				Main: debug.Module{Version: "v1.0.0"},
+			},
+			expected: "v1.2.3",
+		},
+		{
+			desc:    "version of the module if installed by go install",
+			version: "dev",
+			info: &debug.BuildInfo{
+				Main: debug.Module{Version: "v1.0.0"},
+			},
+			expected: "v1.0.0",
+		},
+		{
+			desc:    "development version of the module keeps the default version",
+			version: "dev",
+			info: &debug.BuildInfo{
+				Main: debug.Module{Version: "(devel)"},
+			},
+			expected: "dev",
+		},
+		{
+			desc:    "commit is shortened and given along with the date",
+			version: "v1.2.3",
+			info: &debug.BuildInfo{
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.revision", Value: "5f69dd5a1b2c3d4e5f60718293a4b5c6d7e8f901"},
+					{Key: "vcs.time", Value: "2024-01-02T15:04:05Z"},
+					{Key: "vcs.modified", Value: "false"},
+				},
+			},
+			expected: "v1.2.3 (commit 5f69dd5a1b2c, built 2024-01-02T15:04:05Z)",
+		},
+		{
+			desc:    "modified working tree marks the commit as dirty",
+			version: "v1.2.3",
+			info: &debug.BuildInfo{
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.revision", Value: "5f69dd5"},
+					{Key: "vcs.modified", Value: "true"},
+				},
+			},
+			expected: "v1.2.3 (commit 5f69dd5+dirty)",
+		},
+		{
+			desc:    "date without commit is left out",
+			version: "v1.2.3",
+			info: &debug.BuildInfo{
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.time", Value: "2024-01-02T15:04:05Z"},
+				},
+			},
+			expected: "v1.2.3",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			defer func(v string) { version = v }(version)
+			version = test.version
+
+			assert.Equal(t, test.expected, versionString(test.info))
+		})
+	}
+}